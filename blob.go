@@ -0,0 +1,301 @@
+package mock
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// MimeTypes contains the MIME types of file type and the types generating them
+var MimeTypes = map[string]string{
+	"application/octet-stream": "bytes",
+	"application/json":         "json",
+	"application/pdf":          "pdf",
+	"image/png":                "png",
+	"image/jpeg":               "jpeg",
+	"text/csv":                 "csv",
+	"text/plain":               "lorem",
+	"text/markdown":            "markdown",
+	"text/html":                "html",
+}
+
+// DefaultMimeType is the MIME type of file type when no format is specified
+const DefaultMimeType = "application/octet-stream"
+
+// ImageSize is the default width and height of png and jpeg types
+var ImageSize = [2]int{16, 16}
+
+// blobTypes contains the types generating binary payloads
+var blobTypes = map[string]bool{
+	"bytes":  true,
+	"base64": true,
+	"hex":    true,
+	"png":    true,
+	"jpeg":   true,
+	"pdf":    true,
+	"csv":    true,
+	"json":   true,
+	"file":   true,
+}
+
+// isBytes report whether v is a byte slice or byte array
+func isBytes(v reflect.Value) bool {
+	return (v.Kind() == reflect.Slice || v.Kind() == reflect.Array) && v.Type().Elem().Kind() == reflect.Uint8
+}
+
+func checkBlob(t *Tag) error {
+	if t.Format == "" {
+		return nil
+	}
+	switch t.Type {
+	case "png", "jpeg":
+		if _, _, err := imageSize(t.Format); err != nil {
+			return err
+		}
+	case "file":
+		if _, ok := MimeTypes[t.Format]; !ok {
+			return NewParamError("format", "MIME type of MimeTypes", t.Format)
+		}
+	}
+	return nil
+}
+
+// imageSize parse the image size format WIDTHxHEIGHT, default ImageSize
+func imageSize(f string) (w, h int, err error) {
+	if f == "" {
+		return ImageSize[0], ImageSize[1], nil
+	}
+	wh := strings.Split(f, "x")
+	if len(wh) == 2 {
+		w, err = strconv.Atoi(wh[0])
+		if err == nil {
+			h, err = strconv.Atoi(wh[1])
+		}
+		if err == nil && w > 0 && h > 0 && w <= 4096 && h <= 4096 {
+			return w, h, nil
+		}
+	}
+	return 0, 0, NewParamError("format", "WIDTHxHEIGHT in [1, 4096]", f)
+}
+
+func (g generator) bytes(n int64) []byte {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = byte(g.rand.Intn(256))
+	}
+	return b
+}
+
+// blob return the binary payload of tag, range is the length of bytes, base64 and hex,
+// the rows of csv, the keys of json and the pages of pdf
+func (g generator) blob(tag Tag) []byte {
+	switch tag.Type {
+	case "base64":
+		return []byte(base64.StdEncoding.EncodeToString(g.bytes(g.length(tag))))
+	case "hex":
+		return []byte(hex.EncodeToString(g.bytes(g.length(tag))))
+	case "png", "jpeg":
+		return g.image(tag)
+	case "pdf":
+		return g.pdf(g.length(tag))
+	case "csv":
+		return g.csv(tag, g.length(tag))
+	case "json":
+		return g.json(g.length(tag))
+	case "file":
+		mime := tag.Format
+		if mime == "" {
+			mime = DefaultMimeType
+		}
+		tag.Type, tag.Format = MimeTypes[mime], ""
+		if blobTypes[tag.Type] {
+			return g.blob(tag)
+		}
+		return []byte(g.string(tag))
+	default:
+		return g.bytes(g.length(tag))
+	}
+}
+
+// image return a png or jpeg image of 8x8 blocks in random colors
+func (g generator) image(tag Tag) []byte {
+	w, h, _ := imageSize(tag.Format)
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y += 8 {
+		for x := 0; x < w; x += 8 {
+			c := color.RGBA{byte(g.rand.Intn(256)), byte(g.rand.Intn(256)), byte(g.rand.Intn(256)), 255}
+			for dy := y; dy < y+8 && dy < h; dy++ {
+				for dx := x; dx < x+8 && dx < w; dx++ {
+					img.SetRGBA(dx, dy, c)
+				}
+			}
+		}
+	}
+	var b bytes.Buffer
+	if tag.Type == "jpeg" {
+		jpeg.Encode(&b, img, nil)
+	} else {
+		png.Encode(&b, img)
+	}
+	return b.Bytes()
+}
+
+// pdf return a PDF document of n letter pages with lorem text
+func (g generator) pdf(n int64) []byte {
+	var b bytes.Buffer
+	var offsets []int
+	obj := func(body string) {
+		offsets = append(offsets, b.Len())
+		fmt.Fprintf(&b, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+	b.WriteString("%PDF-1.4\n")
+	kids := make([]string, n)
+	for i := range kids {
+		kids[i] = fmt.Sprintf("%d 0 R", 4+2*i)
+	}
+	obj("<< /Type /Catalog /Pages 2 0 R >>")
+	obj(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), n))
+	obj("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>")
+	escape := strings.NewReplacer(`\`, `\\`, "(", `\(`, ")", `\)`)
+	for i := int64(0); i < n; i++ {
+		obj(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] "+
+			"/Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>", 5+2*i))
+		lines := make([]string, g.int63n(5)+3)
+		for j := range lines {
+			lines[j] = "(" + escape.Replace(g.paragraph(Tag{}, 1)) + ") Tj"
+		}
+		content := "BT /F1 12 Tf 16 TL 72 720 Td " + strings.Join(lines, " T* ") + " ET"
+		obj(fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content))
+	}
+	xref := b.Len()
+	fmt.Fprintf(&b, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&b, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&b, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return b.Bytes()
+}
+
+// csv return a csv document with a header and n rows of id, name, email, city and amount
+func (g generator) csv(tag Tag, n int64) []byte {
+	l := tagLocale(tag)
+	var b bytes.Buffer
+	w := csv.NewWriter(&b)
+	w.Write([]string{"id", "name", "email", "city", "amount"})
+	for i := int64(1); i <= n; i++ {
+		w.Write([]string{
+			strconv.FormatInt(i, 10),
+			g.fullname(l),
+			g.localeEmail(l),
+			g.pick(l.Cities),
+			fmt.Sprintf("%d.%02d", g.int63n(1000), g.int63n(100)),
+		})
+	}
+	w.Flush()
+	return b.Bytes()
+}
+
+// json return a json object with n word keys and values of string, integer, number or bool
+func (g generator) json(n int64) []byte {
+	obj := make(map[string]interface{}, n)
+	for int64(len(obj)) < n {
+		var val interface{}
+		switch g.int63n(4) {
+		case 0:
+			val = g.pick(LoremWords)
+		case 1:
+			val = g.int63n(1000)
+		case 2:
+			val = float64(g.int63n(100000)) / 100
+		default:
+			val = g.int63n(2) == 0
+		}
+		obj[g.word(3, 9)] = val
+	}
+	b, _ := json.Marshal(obj)
+	return b
+}
+
+// mockBlob fill the byte slice or byte array v with the payload of t
+func (m *mocker) mockBlob(t Tag, v reflect.Value) {
+	if !isBytes(v) {
+		m.err = NewConflictError("fieldType", v.Type().String(), "type", t.Type, t.Type+" need string, []byte or byte array")
+		return
+	}
+	if v.Kind() == reflect.Array {
+		if t.Type == "" || t.Type == "bytes" {
+			reflect.Copy(v, reflect.ValueOf(m.gen.bytes(int64(v.Len()))))
+			return
+		}
+		m.err = NewConflictError("fieldType", v.Type().String(), "type", t.Type, t.Type+" need []byte")
+		return
+	}
+	v.SetBytes(m.gen.blob(t))
+}
+
+// blobBytes return the bytes of the byte slice or byte array v
+func blobBytes(v reflect.Value) []byte {
+	if v.Kind() == reflect.Slice {
+		return v.Bytes()
+	}
+	b := make([]byte, v.Len())
+	reflect.Copy(reflect.ValueOf(b), v)
+	return b
+}
+
+// validBlob return why b is not a valid payload of the binary types, or "" if it is valid
+func validBlob(t Tag, b []byte) string {
+	ok := true
+	switch t.Type {
+	case "base64":
+		_, err := base64.StdEncoding.DecodeString(string(b))
+		ok = err == nil
+	case "hex":
+		_, err := hex.DecodeString(string(b))
+		ok = err == nil
+	case "png", "jpeg":
+		decode := png.DecodeConfig
+		if t.Type == "jpeg" {
+			decode = jpeg.DecodeConfig
+		}
+		c, err := decode(bytes.NewReader(b))
+		ok = err == nil
+		if ok && t.Format != "" {
+			w, h, _ := imageSize(t.Format)
+			ok = c.Width == w && c.Height == h
+		}
+	case "pdf":
+		ok = bytes.HasPrefix(b, []byte("%PDF-")) && bytes.Contains(b, []byte("%%EOF"))
+	case "csv":
+		records, err := csv.NewReader(bytes.NewReader(b)).ReadAll()
+		ok = err == nil && len(records) > 0
+	case "json":
+		ok = json.Valid(b)
+	case "file":
+		mime := t.Format
+		if mime == "" {
+			mime = DefaultMimeType
+		}
+		t.Type, t.Format = MimeTypes[mime], ""
+		if blobTypes[t.Type] {
+			return validBlob(t, b)
+		}
+		return validText(t, string(b))
+	default:
+		return ""
+	}
+	if !ok {
+		return "not a valid " + t.Type
+	}
+	return ""
+}
@@ -0,0 +1,191 @@
+package mock
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// UniqueRetry is the max times to regenerate a duplicate value of an unique field
+const UniqueRetry = 100
+
+// DefaultTagKey is the struct tag key of mock tags when no TagKey is specified
+const DefaultTagKey = "mock"
+
+// StructConfig configures the fields of a struct type without struct tags, see Mocker.For
+type StructConfig struct {
+	m      *mocker
+	typ    reflect.Type
+	fields map[string]*FieldConfig
+	err    error
+}
+
+// FieldConfig configures the tags of a field, the methods append tag funcs and return itself
+type FieldConfig struct {
+	s      *StructConfig
+	tags   []string
+	unique bool
+	seen   map[string]bool
+}
+
+func (m *mocker) SetTagKey(key string) {
+	m.tagKey = key
+}
+
+// For return the StructConfig of the struct type of data, data is a struct or a pointer to struct,
+// otherwise Err of the StructConfig return a ParamError
+func (m *mocker) For(data interface{}) *StructConfig {
+	typ := reflect.TypeOf(data)
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ == nil || typ.Kind() != reflect.Struct {
+		// the invalid configuration is not cached, only Err reports it
+		return &StructConfig{m: m, typ: typ, fields: map[string]*FieldConfig{},
+			err: NewParamError("data", "struct or pointer to struct", data)}
+	}
+	if m.structs == nil {
+		m.structs = map[reflect.Type]*StructConfig{}
+	}
+	if s, ok := m.structs[typ]; ok {
+		return s
+	}
+	s := &StructConfig{m: m, typ: typ, fields: map[string]*FieldConfig{}}
+	m.structs[typ] = s
+	return s
+}
+
+// Err return the error of the configuration, e.g. an unknown field,
+// Mock and Valid of the struct type return it too
+func (s *StructConfig) Err() error {
+	return s.err
+}
+
+// Field return the FieldConfig of the field by Go name, the configured tags
+// replace the struct tag of the field
+func (s *StructConfig) Field(name string) *FieldConfig {
+	if s.err == nil {
+		if _, ok := s.typ.FieldByName(name); !ok {
+			s.err = NewParamError("field", "field of "+s.typ.String(), name)
+		}
+	}
+	if f, ok := s.fields[name]; ok {
+		return f
+	}
+	f := &FieldConfig{s: s}
+	s.fields[name] = f
+	return f
+}
+
+// Field return the FieldConfig of another field of the same struct
+func (f *FieldConfig) Field(name string) *FieldConfig {
+	return f.s.Field(name)
+}
+
+func (f *FieldConfig) add(fn string, params ...interface{}) *FieldConfig {
+	ps := make([]string, len(params))
+	for i, p := range params {
+		ps[i] = fmt.Sprint(p)
+	}
+	f.tags = append(f.tags, fn+"("+strings.Join(ps, ", ")+")")
+	return f
+}
+
+// Tags append raw tags, e.g. Tags("type(word) range(3, 8)")
+func (f *FieldConfig) Tags(tags string) *FieldConfig {
+	f.tags = append(f.tags, tags)
+	return f
+}
+
+// Type set the type tag func
+func (f *FieldConfig) Type(name string) *FieldConfig {
+	return f.add("type", name)
+}
+
+// Range set the range tag func, e.g. Range(1, 100)
+func (f *FieldConfig) Range(bounds ...interface{}) *FieldConfig {
+	return f.add("range", bounds...)
+}
+
+// Value set the value tag func
+func (f *FieldConfig) Value(values ...interface{}) *FieldConfig {
+	return f.add("value", values...)
+}
+
+// Pattern set the pattern tag func
+func (f *FieldConfig) Pattern(re string) *FieldConfig {
+	return f.add("pattern", re)
+}
+
+// Format set the format tag func
+func (f *FieldConfig) Format(format string) *FieldConfig {
+	return f.add("format", format)
+}
+
+// Locale set the locale tag func
+func (f *FieldConfig) Locale(name string) *FieldConfig {
+	return f.add("locale", name)
+}
+
+// Elem set the elem tag func
+func (f *FieldConfig) Elem(tags string) *FieldConfig {
+	return f.add("elem", tags)
+}
+
+// Skip skip the field like the tag "-"
+func (f *FieldConfig) Skip() *FieldConfig {
+	f.tags = []string{"-"}
+	return f
+}
+
+// Unique regenerate the field until the value is not generated before by the Mocker,
+// at most UniqueRetry times
+func (f *FieldConfig) Unique() *FieldConfig {
+	f.unique = true
+	return f
+}
+
+// structConfig return the StructConfig of struct type t, and set m.err to its error
+func (m *mocker) structConfig(t reflect.Type) *StructConfig {
+	s := m.structs[t]
+	if s != nil && s.err != nil {
+		m.err = s.err
+	}
+	return s
+}
+
+// field return the FieldConfig of the field by Go name, s may be nil
+func (s *StructConfig) field(name string) (*FieldConfig, bool) {
+	if s == nil {
+		return nil, false
+	}
+	f, ok := s.fields[name]
+	return f, ok
+}
+
+// mockUnique mock v by tags until the value is not in f.seen
+func (m *mocker) mockUnique(f *FieldConfig, path, tags string, v reflect.Value) {
+	if f.seen == nil {
+		f.seen = map[string]bool{}
+	}
+	// stop at the errors of v only, the earlier error is kept
+	prev := m.err
+	m.err = nil
+	defer func() {
+		if prev != nil {
+			m.err = prev
+		}
+	}()
+	for i := 0; i < UniqueRetry && m.err == nil; i++ {
+		m.mock(path, tags, v)
+		key := fmt.Sprint(v.Interface())
+		if !f.seen[key] {
+			f.seen[key] = true
+			return
+		}
+		v.Set(reflect.Zero(v.Type()))
+	}
+	if m.err == nil {
+		m.err = NewInvalidError(path, nil, fmt.Sprintf("no unique value in %d retries", UniqueRetry))
+	}
+}
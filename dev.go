@@ -0,0 +1,314 @@
+package mock
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// JWTKey is the fixed test key of HS256 jwt
+var JWTKey = []byte("mock-jwt-test-key")
+
+// JWTAlgs is the avaliable algorithms in format tag func of jwt, default HS256
+var JWTAlgs = []string{"HS256", "none"}
+
+// MimeTypeList is the MIME types of mimetype type, besides the keys of MimeTypes
+var MimeTypeList = []string{
+	"application/gzip", "application/javascript", "application/xml", "application/zip",
+	"audio/mpeg", "audio/ogg", "font/woff2", "image/gif", "image/svg+xml", "image/webp",
+	"text/css", "video/mp4", "video/webm",
+}
+
+// FileExtensions is the extensions of filepath type
+var FileExtensions = []string{"go", "txt", "md", "json", "yaml", "csv", "png", "jpg", "pdf", "html", "log"}
+
+// defaultVersions is the version range of semver without range tag func
+var defaultVersions = [2]string{"0.1.0", "10.0.0"}
+
+var (
+	versionRe   = regexp.MustCompile(`^(\d+)\.(\d+)\.(\d+)$`)
+	semverRe    = regexp.MustCompile(`^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(-[0-9A-Za-z-]+(\.[0-9A-Za-z-]+)*)?(\+[0-9A-Za-z-]+(\.[0-9A-Za-z-]+)*)?$`)
+	hexcolorRe  = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+	rgbRe       = regexp.MustCompile(`^rgb\((\d{1,3}), ?(\d{1,3}), ?(\d{1,3})\)$`)
+	hslRe       = regexp.MustCompile(`^hsl\((\d{1,3}), ?(\d{1,3})%, ?(\d{1,3})%\)$`)
+	useragentRe = regexp.MustCompile(`^Mozilla/5\.0 \([^)]+\) \S.*$`)
+	sha256Re    = regexp.MustCompile(`^[0-9a-f]{64}$`)
+)
+
+// version is the major, minor and patch of a semantic version
+type version [3]int64
+
+func parseVersion(s string) (v version, ok bool) {
+	m := versionRe.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return v, false
+	}
+	for i := range v {
+		var err error
+		if v[i], err = strconv.ParseInt(m[i+1], 10, 64); err != nil {
+			return v, false
+		}
+	}
+	return v, true
+}
+
+func (v version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v[0], v[1], v[2])
+}
+
+func (v version) less(o version) bool {
+	for i := range v {
+		if v[i] != o[i] {
+			return v[i] < o[i]
+		}
+	}
+	return false
+}
+
+// isVersionRange report whether a bound of range is a version, e.g. range(1.0.0, 2.0.0)
+func isVersionRange(lo, hi string) bool {
+	return versionRe.MatchString(lo) || versionRe.MatchString(hi)
+}
+
+// parseVersionRange parse the version range of semver, range(v) means [0.0.0, v)
+func parseVersionRange(t *Tag, lo, hi string, single bool) error {
+	if single {
+		lo, hi = "0.0.0", lo
+	}
+	min, ok := parseVersion(lo)
+	if !ok {
+		return NewParamError("range", "version", lo)
+	}
+	max, ok := parseVersion(hi)
+	if !ok {
+		return NewParamError("range", "version", hi)
+	}
+	if max.less(min) {
+		return NewParamError("range", "min <= max", fmt.Sprintf("min: %s > max: %s", min, max))
+	}
+	t.MinVersion, t.MaxVersion = min.String(), max.String()
+	return nil
+}
+
+// checkDev check the conflicts of developer types after all tag funcs are parsed
+func checkDev(t *Tag) error {
+	if t.MinVersion != "" && t.Type != "semver" {
+		return NewConflictError("type", t.Type, "range", t.MinVersion+","+t.MaxVersion, "version range need type semver")
+	}
+	if t.Type == "jwt" && t.Format != "" && !contains(JWTAlgs, t.Format) {
+		return NewParamError("format", strings.Join(JWTAlgs, "/"), t.Format)
+	}
+	return nil
+}
+
+// versionBounds return the inclusive version bounds of tag
+func (t Tag) versionBounds() (lo, hi version) {
+	min, max := t.MinVersion, t.MaxVersion
+	if min == "" {
+		min, max = defaultVersions[0], defaultVersions[1]
+	}
+	lo, _ = parseVersion(min)
+	hi, _ = parseVersion(max)
+	return lo, hi
+}
+
+// semver return a version in the version range of tag, the minor and patch
+// are below 20 unless the bounds need bigger ones
+func (g generator) semver(tag Tag) string {
+	lo, hi := tag.versionBounds()
+	base := int64(20)
+	for _, n := range []int64{lo[1], lo[2], hi[1], hi[2]} {
+		if n >= base {
+			base = n + 1
+		}
+	}
+	index := func(v version) int64 { return (v[0]*base+v[1])*base + v[2] }
+	min, max := index(lo), index(hi)
+	if tag.MinExclusive {
+		min++
+	}
+	if tag.MaxInclusive {
+		max++
+	}
+	n := min
+	if max > min {
+		n += g.int63n(max - min)
+	}
+	return version{n / base / base, n / base % base, n % base}.String()
+}
+
+func (g generator) hexcolor() string {
+	return fmt.Sprintf("#%02x%02x%02x", g.int63n(256), g.int63n(256), g.int63n(256))
+}
+
+func (g generator) rgb() string {
+	return fmt.Sprintf("rgb(%d, %d, %d)", g.int63n(256), g.int63n(256), g.int63n(256))
+}
+
+func (g generator) hsl() string {
+	return fmt.Sprintf("hsl(%d, %d%%, %d%%)", g.int63n(360), g.int63n(101), g.int63n(101))
+}
+
+// mimeTypes return the sorted MIME types of mimetype type
+func mimeTypes() []string {
+	list := append([]string(nil), MimeTypeList...)
+	for k := range MimeTypes {
+		list = append(list, k)
+	}
+	sort.Strings(list)
+	return list
+}
+
+// filepath return a random absolute unix file path
+func (g generator) filepath() string {
+	dirs := make([]string, g.int63n(3)+1)
+	for i := range dirs {
+		dirs[i] = g.word(2, 9)
+	}
+	return "/" + strings.Join(dirs, "/") + "/" + g.word(3, 10) + "." + g.pick(FileExtensions)
+}
+
+// useragent return a user agent of chrome, firefox or safari
+func (g generator) useragent() string {
+	platform := g.pick([]string{
+		"Windows NT 10.0; Win64; x64",
+		"Macintosh; Intel Mac OS X 10_15_7",
+		"X11; Linux x86_64",
+	})
+	major := g.int63n(40) + 90
+	switch g.int63n(3) {
+	case 0:
+		return fmt.Sprintf("Mozilla/5.0 (%s; rv:%d.0) Gecko/20100101 Firefox/%d.0", platform, major, major)
+	case 1:
+		return fmt.Sprintf("Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 "+
+			"(KHTML, like Gecko) Version/%d.%d Safari/605.1.15", g.int63n(5)+14, g.int63n(6))
+	default:
+		return fmt.Sprintf("Mozilla/5.0 (%s) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/%d.0.%d.%d Safari/537.36",
+			platform, major, g.int63n(5000), g.int63n(200))
+	}
+}
+
+// jwt return a token of sub, name, iat and exp claims, signed by JWTKey with HS256 unless format is none
+func (g generator) jwt(tag Tag) string {
+	alg := tag.Format
+	if alg == "" {
+		alg = JWTAlgs[0]
+	}
+	now := g.now()
+	header, _ := json.Marshal(map[string]string{"alg": alg, "typ": "JWT"})
+	claims, _ := json.Marshal(map[string]interface{}{
+		"sub":  idString("uuid", g.uuid("")),
+		"name": g.fullname(tagLocale(tag)),
+		"iat":  now.Unix(),
+		"exp":  now.Add(time.Hour).Unix(),
+	})
+	enc := base64.RawURLEncoding
+	token := enc.EncodeToString(header) + "." + enc.EncodeToString(claims)
+	if alg == "none" {
+		return token + "."
+	}
+	return token + "." + enc.EncodeToString(jwtSign(token))
+}
+
+func jwtSign(token string) []byte {
+	mac := hmac.New(sha256.New, JWTKey)
+	mac.Write([]byte(token))
+	return mac.Sum(nil)
+}
+
+// validJWT report whether s is a jwt of alg, HS256 tokens must be signed by JWTKey
+func validJWT(s, alg string) bool {
+	parts := strings.Split(s, ".")
+	if len(parts) != 3 {
+		return false
+	}
+	enc := base64.RawURLEncoding
+	var header struct{ Alg string }
+	b, err := enc.DecodeString(parts[0])
+	if err != nil || json.Unmarshal(b, &header) != nil {
+		return false
+	}
+	if b, err = enc.DecodeString(parts[1]); err != nil || !json.Valid(b) {
+		return false
+	}
+	if alg != "" && header.Alg != alg {
+		return false
+	}
+	switch header.Alg {
+	case "none":
+		return parts[2] == ""
+	case "HS256":
+		sig, err := enc.DecodeString(parts[2])
+		return err == nil && hmac.Equal(sig, jwtSign(parts[0]+"."+parts[1]))
+	}
+	return false
+}
+
+func (g generator) sha256() string {
+	sum := sha256.Sum256(g.bytes(32))
+	return hex.EncodeToString(sum[:])
+}
+
+// inLimits report whether the submatches of re in s are all below the limits
+func inLimits(re *regexp.Regexp, s string, limits ...int64) bool {
+	m := re.FindStringSubmatch(s)
+	if m == nil {
+		return false
+	}
+	for i, limit := range limits {
+		if n, err := strconv.ParseInt(m[i+1], 10, 64); err != nil || n >= limit {
+			return false
+		}
+	}
+	return true
+}
+
+// validDev return why s is not a valid value of the developer types, or "" if it is valid
+func validDev(t Tag, s string) string {
+	ok := true
+	switch t.Type {
+	case "hexcolor":
+		ok = hexcolorRe.MatchString(s)
+	case "rgb":
+		ok = inLimits(rgbRe, s, 256, 256, 256)
+	case "hsl":
+		ok = inLimits(hslRe, s, 360, 101, 101)
+	case "semver":
+		ok = semverRe.MatchString(s)
+		if ok && t.MinVersion != "" {
+			v, _ := parseVersion(strings.FieldsFunc(s, func(r rune) bool { return r == '-' || r == '+' })[0])
+			lo, hi := t.versionBounds()
+			ok = !v.less(lo) && (v.less(hi) || t.MaxInclusive && v == hi) && !(t.MinExclusive && v == lo)
+			if !ok {
+				return "version out of range"
+			}
+		}
+	case "mimetype":
+		_, _, err := mime.ParseMediaType(s)
+		ok = err == nil && strings.Contains(s, "/")
+	case "filepath":
+		ok = strings.HasPrefix(s, "/") && path.Clean(s) == s
+	case "useragent":
+		ok = useragentRe.MatchString(s)
+	case "jwt":
+		ok = validJWT(s, t.Format)
+	case "sha256":
+		ok = sha256Re.MatchString(s)
+	default:
+		return ""
+	}
+	if !ok {
+		return "not a valid " + t.Type
+	}
+	return ""
+}
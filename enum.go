@@ -0,0 +1,173 @@
+package mock
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"reflect"
+	"sort"
+)
+
+// RegisterEnum register the values of the enum type typ, fields of typ are mocked
+// with one of values, and Valid rejects the other values. The kinds of values need
+// match the underlying kind of typ, the integers of any size and sign are matched,
+// and the values need fit in typ
+func (m *mocker) RegisterEnum(typ reflect.Type, values ...interface{}) error {
+	if len(values) == 0 {
+		return NewParamError("values", "at least one value", 0)
+	}
+	vals := make([]reflect.Value, len(values))
+	for i, val := range values {
+		rv := reflect.ValueOf(val)
+		if rv.IsValid() && kindClass(rv.Kind()) != kindClass(typ.Kind()) {
+			return NewParamError("values", typ.Kind().String()+" values of "+typ.String(), val)
+		}
+		if !rv.IsValid() || !rv.Type().ConvertibleTo(typ) {
+			return NewConflictError("enum", typ.String(), "value", val, "value need be convertible to the enum type")
+		}
+		if overflows(rv, typ) {
+			return NewConflictError("enum", typ.String(), "value", val, "value overflows the enum type")
+		}
+		vals[i] = rv.Convert(typ)
+	}
+	if m.enums == nil {
+		m.enums = map[reflect.Type][]reflect.Value{}
+	}
+	m.enums[typ] = vals
+	return nil
+}
+
+// kindClass return reflect.Int for the integer kinds, reflect.Float64 for the float kinds,
+// reflect.Complex128 for the complex kinds, and k for the others
+func kindClass(k reflect.Kind) reflect.Kind {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return reflect.Int
+	case reflect.Float32, reflect.Float64:
+		return reflect.Float64
+	case reflect.Complex64, reflect.Complex128:
+		return reflect.Complex128
+	}
+	return k
+}
+
+// mockEnum set v to a random registered value of its type, and report whether v is an enum
+func (m *mocker) mockEnum(v reflect.Value) bool {
+	vals, ok := m.enums[v.Type()]
+	if ok {
+		v.Set(vals[m.gen.int63n(int64(len(vals)))])
+	}
+	return ok
+}
+
+// validEnum valid v is a registered value of its type, and report whether v is an enum
+func (m *mocker) validEnum(path string, v reflect.Value) bool {
+	vals, ok := m.enums[v.Type()]
+	if !ok {
+		return false
+	}
+	for _, val := range vals {
+		if reflect.DeepEqual(val.Interface(), v.Interface()) {
+			return true
+		}
+	}
+	m.err = NewInvalidError(path, v.Interface(), fmt.Sprintf("not a registered %s", v.Type()))
+	return true
+}
+
+// EnumConst is a constant of a enum type found by ParseEnum
+type EnumConst struct {
+	Name  string
+	Value interface{} // int64, uint64, float64, string or bool
+}
+
+// ParseEnum parse the go files in dir and return the constants of the named type typeName
+// in declaration order, e.g. the const block of StatusActive Status = iota, only constants
+// depending on the package itself are evaluated
+func ParseEnum(dir, typeName string) ([]EnumConst, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(pkgs))
+	for name := range pkgs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var consts []EnumConst
+	for _, name := range names {
+		var files []*ast.File
+		for _, f := range pkgs[name].Files {
+			files = append(files, f)
+		}
+		conf := types.Config{
+			Importer: noImporter{},
+			Error:    func(error) {},
+		}
+		pkg, _ := conf.Check(name, fset, files, nil)
+		if pkg == nil {
+			continue
+		}
+		var found []*types.Const
+		scope := pkg.Scope()
+		for _, n := range scope.Names() {
+			c, ok := scope.Lookup(n).(*types.Const)
+			if !ok {
+				continue
+			}
+			if named, ok := c.Type().(*types.Named); ok && named.Obj().Name() == typeName {
+				found = append(found, c)
+			}
+		}
+		sort.Slice(found, func(i, j int) bool { return found[i].Pos() < found[j].Pos() })
+		for _, c := range found {
+			consts = append(consts, EnumConst{Name: c.Name(), Value: constValue(c.Val())})
+		}
+	}
+	if len(consts) == 0 {
+		return nil, NewParamError("typeName", "named type with constants in "+dir, typeName)
+	}
+	return consts, nil
+}
+
+// EnumValues return the values of consts for RegisterEnum
+func EnumValues(consts []EnumConst) []interface{} {
+	vals := make([]interface{}, len(consts))
+	for i, c := range consts {
+		vals[i] = c.Value
+	}
+	return vals
+}
+
+func constValue(v constant.Value) interface{} {
+	switch v.Kind() {
+	case constant.Int:
+		if n, ok := constant.Int64Val(v); ok {
+			return n
+		}
+		n, _ := constant.Uint64Val(v)
+		return n
+	case constant.Float:
+		f, _ := constant.Float64Val(v)
+		return f
+	case constant.String:
+		return constant.StringVal(v)
+	case constant.Bool:
+		return constant.BoolVal(v)
+	}
+	return nil
+}
+
+// noImporter fails all imports, ParseEnum only needs the constants of the package itself
+type noImporter struct{}
+
+func (noImporter) Import(path string) (*types.Package, error) {
+	return nil, errors.New("import is not supported")
+}
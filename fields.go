@@ -0,0 +1,86 @@
+package mock
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// indexRe matches the slice, array and map indexes of a path
+var indexRe = regexp.MustCompile(`\[[^\]]*\]`)
+
+func (m *mocker) SetNameTag(key string) {
+	m.nameTag = key
+}
+
+func (m *mocker) SetOmitEmpty(p float64) {
+	m.omitEmpty = p
+}
+
+func (m *mocker) SetFields(fields map[string]string) {
+	m.fields = fields
+}
+
+// fieldName return the name of tf in paths and Fields, the name in the NameTag of tf
+// if any, and report whether tf has omitempty option or should be skipped by "-"
+func (m *mocker) fieldName(tf reflect.StructField) (name string, omitempty, skip bool) {
+	name = tf.Name
+	if m.nameTag == "" {
+		return name, false, false
+	}
+	tag, ok := tf.Tag.Lookup(m.nameTag)
+	if !ok {
+		return name, false, false
+	}
+	if tag == "-" {
+		return name, false, true
+	}
+	opts := strings.Split(tag, ",")
+	if opts[0] != "" {
+		name = opts[0]
+	}
+	for _, opt := range opts[1:] {
+		if opt == "omitempty" {
+			omitempty = true
+		}
+	}
+	return name, omitempty, false
+}
+
+// fieldTags return the tags of the field tf at path, the tags in Fields override the tags
+// configured by For, which override the struct tag of TagKey, then the rules of ValidateTag
+// translated by ValidatorTags, the indexes of path are ignored, e.g. Fields["users.email"]
+// applies to users[0].email
+func (m *mocker) fieldTags(path string, s *StructConfig, tf reflect.StructField) string {
+	if tags, ok := m.fields[indexRe.ReplaceAllString(path, "")]; ok {
+		return tags
+	}
+	if s != nil {
+		if f, ok := s.fields[tf.Name]; ok && len(f.tags) > 0 {
+			return strings.Join(f.tags, " ")
+		}
+	}
+	key := m.tagKey
+	if key == "" {
+		key = DefaultTagKey
+	}
+	if tags, ok := tf.Tag.Lookup(key); ok || m.validateTag == "" {
+		return tags
+	}
+	rules, ok := tf.Tag.Lookup(m.validateTag)
+	if !ok {
+		return ""
+	}
+	tags, err := ValidatorTags(rules, tf.Type)
+	if err != nil && m.err == nil {
+		m.err = err
+	}
+	return tags
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
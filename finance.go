@@ -0,0 +1,278 @@
+package mock
+
+import (
+	"math"
+	"math/big"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// IBANFormats contains the BBAN formats of IBAN countries, 'n' is a digit, 'a' is a upper letter
+// and 'c' is a digit or upper letter
+var IBANFormats = map[string]string{
+	"DE": "nnnnnnnnnnnnnnnnnn",
+	"FR": "nnnnnnnnnncccccccccccnn",
+	"GB": "aaaannnnnnnnnnnnnn",
+	"NL": "aaaannnnnnnnnn",
+	"ES": "nnnnnnnnnnnnnnnnnnnn",
+	"IT": "annnnnnnnnncccccccccccc",
+	"CH": "nnnnncccccccccccc",
+	"AT": "nnnnnnnnnnnnnnnn",
+	"BE": "nnnnnnnnnnnn",
+	"PL": "nnnnnnnnnnnnnnnnnnnnnnnn",
+}
+
+// CardNetwork descripe the prefixes and length of a credit card network
+type CardNetwork struct {
+	Prefixes [][2]int // inclusive prefix ranges
+	Length   int
+}
+
+// CardNetworks contains the avaliable networks in format tag func of creditcard
+var CardNetworks = map[string]CardNetwork{
+	"visa":       {Prefixes: [][2]int{{4, 4}}, Length: 16},
+	"mastercard": {Prefixes: [][2]int{{51, 55}, {2221, 2720}}, Length: 16},
+	"amex":       {Prefixes: [][2]int{{34, 34}, {37, 37}}, Length: 15},
+	"discover":   {Prefixes: [][2]int{{6011, 6011}, {644, 649}, {65, 65}}, Length: 16},
+	"jcb":        {Prefixes: [][2]int{{3528, 3589}}, Length: 16},
+	"unionpay":   {Prefixes: [][2]int{{62, 62}}, Length: 16},
+}
+
+// Currencies contains the ISO 4217 currency codes and their minor units
+var Currencies = map[string]int{
+	"USD": 2, "EUR": 2, "CNY": 2, "JPY": 0, "GBP": 2, "AUD": 2, "CAD": 2, "CHF": 2, "HKD": 2, "SGD": 2,
+	"SEK": 2, "NOK": 2, "DKK": 2, "NZD": 2, "KRW": 0, "INR": 2, "RUB": 2, "BRL": 2, "MXN": 2, "ZAR": 2,
+	"TRY": 2, "PLN": 2, "THB": 2, "IDR": 2, "HUF": 2, "CZK": 2, "ILS": 2, "CLP": 0, "PHP": 2, "AED": 2,
+	"SAR": 2, "MYR": 2, "VND": 0, "ISK": 0, "KWD": 3, "BHD": 3, "OMR": 3, "JOD": 3, "TND": 3, "LYD": 3,
+}
+
+const alnumUpper = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+var (
+	ibanRe = regexp.MustCompile(`^[A-Z]{2}\d{2}[A-Z0-9]+$`)
+	bicRe  = regexp.MustCompile(`^[A-Z]{4}[A-Z]{2}[A-Z0-9]{2}([A-Z0-9]{3})?$`)
+)
+
+// DefaultCurrency is the currency of amount without format tag func
+const DefaultCurrency = "USD"
+
+// checkFinance check the format of financial types after all tag funcs are parsed
+func checkFinance(t *Tag) error {
+	if t.Format == "" {
+		return nil
+	}
+	switch t.Type {
+	case "iban":
+		if _, ok := IBANFormats[t.Format]; !ok {
+			return NewParamError("format", "IBAN country code", t.Format)
+		}
+	case "creditcard":
+		if _, ok := CardNetworks[t.Format]; !ok {
+			return NewParamError("format", "card network", t.Format)
+		}
+	case "amount":
+		if _, ok := Currencies[t.Format]; !ok {
+			return NewParamError("format", "ISO 4217 currency code", t.Format)
+		}
+	}
+	return nil
+}
+
+// ibanCountries return the sorted country codes of IBANFormats
+func ibanCountries() []string {
+	countries := make([]string, 0, len(IBANFormats))
+	for c := range IBANFormats {
+		countries = append(countries, c)
+	}
+	sort.Strings(countries)
+	return countries
+}
+
+// cardNetworks return the sorted names of CardNetworks
+func cardNetworks() []string {
+	networks := make([]string, 0, len(CardNetworks))
+	for n := range CardNetworks {
+		networks = append(networks, n)
+	}
+	sort.Strings(networks)
+	return networks
+}
+
+// currencyCodes return the sorted codes of Currencies
+func currencyCodes() []string {
+	codes := make([]string, 0, len(Currencies))
+	for c := range Currencies {
+		codes = append(codes, c)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+func (g generator) iban(country string) string {
+	if country == "" {
+		country = g.pick(ibanCountries())
+	}
+	var b strings.Builder
+	for _, c := range IBANFormats[country] {
+		switch c {
+		case 'n':
+			b.WriteByte(byte('0' + g.int63n(10)))
+		case 'a':
+			b.WriteByte(byte('A' + g.int63n(26)))
+		default:
+			b.WriteByte(alnumUpper[g.int63n(int64(len(alnumUpper)))])
+		}
+	}
+	bban := b.String()
+	check := 98 - ibanMod97(bban+country+"00")
+	return country + strconv.Itoa(check/10) + strconv.Itoa(check%10) + bban
+}
+
+// ibanMod97 return the ISO 7064 mod 97 of s with letters replaced by 10 to 35
+func ibanMod97(s string) int {
+	var b strings.Builder
+	for _, c := range s {
+		if c >= 'A' && c <= 'Z' {
+			b.WriteString(strconv.Itoa(int(c-'A') + 10))
+		} else {
+			b.WriteRune(c)
+		}
+	}
+	n, _ := new(big.Int).SetString(b.String(), 10)
+	return int(new(big.Int).Mod(n, big.NewInt(97)).Int64())
+}
+
+func validIBAN(s string) bool {
+	s = strings.ToUpper(strings.Replace(s, " ", "", -1))
+	if len(s) < 5 {
+		return false
+	}
+	f, ok := IBANFormats[s[:2]]
+	if !ok || len(s) != len(f)+4 || !ibanRe.MatchString(s) {
+		return false
+	}
+	return ibanMod97(s[4:]+s[:4]) == 1
+}
+
+func (g generator) creditcard(network string) string {
+	if network == "" {
+		network = g.pick(cardNetworks())
+	}
+	n := CardNetworks[network]
+	p := n.Prefixes[g.int63n(int64(len(n.Prefixes)))]
+	prefix := strconv.Itoa(p[0] + int(g.int63n(int64(p[1]-p[0]+1))))
+	b := []byte(prefix)
+	for len(b) < n.Length-1 {
+		b = append(b, byte('0'+g.int63n(10)))
+	}
+	return string(append(b, luhnDigit(b)))
+}
+
+// luhnDigit return the check digit of the Luhn algorithm for digits
+func luhnDigit(digits []byte) byte {
+	sum := 0
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if (len(digits)-i)%2 == 1 {
+			if d *= 2; d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+func validCreditcard(s, network string) bool {
+	s = strings.Replace(strings.Replace(s, " ", "", -1), "-", "", -1)
+	if len(s) < 12 || len(s) > 19 || strings.Trim(s, "0123456789") != "" {
+		return false
+	}
+	if luhnDigit([]byte(s[:len(s)-1])) != s[len(s)-1] {
+		return false
+	}
+	if network == "" {
+		return true
+	}
+	n := CardNetworks[network]
+	if len(s) != n.Length {
+		return false
+	}
+	for _, p := range n.Prefixes {
+		width := len(strconv.Itoa(p[0]))
+		if prefix, _ := strconv.Atoi(s[:width]); prefix >= p[0] && prefix <= p[1] {
+			return true
+		}
+	}
+	return false
+}
+
+func (g generator) bic() string {
+	var b strings.Builder
+	for i := 0; i < 4; i++ {
+		b.WriteByte(byte('A' + g.int63n(26)))
+	}
+	b.WriteString(g.pick(ibanCountries()))
+	for i := 0; i < 2; i++ {
+		b.WriteByte(alnumUpper[g.int63n(int64(len(alnumUpper)))])
+	}
+	if g.int63n(2) == 0 {
+		b.WriteString("XXX")
+	}
+	return b.String()
+}
+
+// amount return a random amount in the range of tag, rounded to the minor units of the currency
+func (g generator) amount(tag Tag) float64 {
+	units := Currencies[currency(tag)]
+	tag.Type, tag.Step, tag.Precision = "", math.Pow10(-units), units
+	return g.float(tag)
+}
+
+// minorAmount return a random amount in the minor units of the currency, e.g. cents
+func (g generator) minorAmount(tag Tag) int64 {
+	return int64(math.Round(g.amount(tag) * math.Pow10(Currencies[currency(tag)])))
+}
+
+func currency(tag Tag) string {
+	if tag.Format != "" {
+		return tag.Format
+	}
+	return DefaultCurrency
+}
+
+// validAmount report whether n has no more decimal places than the minor units of the currency,
+// the float32 amounts are compared at float32 precision
+func validAmount(tag Tag, n float64, bitSize int) bool {
+	p := math.Pow10(Currencies[currency(tag)])
+	if bitSize == 32 {
+		return float32(math.Round(n*p)/p) == float32(n)
+	}
+	return math.Abs(n*p-math.Round(n*p)) < 1e-6
+}
+
+// validFinance return why s is not a valid value of the financial types, or "" if it is valid
+func validFinance(t Tag, s string) string {
+	ok := true
+	switch t.Type {
+	case "iban":
+		ok = validIBAN(s) && (t.Format == "" || strings.HasPrefix(s, t.Format))
+	case "creditcard":
+		ok = validCreditcard(s, t.Format)
+	case "currency":
+		_, ok = Currencies[s]
+	case "bic":
+		ok = bicRe.MatchString(s)
+	case "amount":
+		n, err := strconv.ParseFloat(s, 64)
+		ok = err == nil && validAmount(t, n, 64)
+	default:
+		return ""
+	}
+	if !ok {
+		return "not a valid " + t.Type
+	}
+	return ""
+}
@@ -2,9 +2,13 @@ package mock
 
 import (
 	"fmt"
+	"math"
 	"math/rand"
+	"reflect"
+	"regexp/syntax"
 	"strings"
 	"time"
+	"unicode"
 )
 
 // Generator gen int uint float and string
@@ -16,13 +20,23 @@ type Generator interface {
 }
 
 type generator struct {
-	rand *rand.Rand
+	rand  *rand.Rand
+	seq   *int64
+	now   func() time.Time
+	types map[string]TypeProvider       // registered types
+	call  func(name string) interface{} // call the GenFunc of tmpl placeholders
 }
 
 // NewGen return a Generator
 func NewGen(rand *rand.Rand) Generator {
+	return newGenerator(rand)
+}
+
+func newGenerator(rand *rand.Rand) generator {
 	return generator{
 		rand: rand,
+		seq:  new(int64),
+		now:  time.Now,
 	}
 }
 
@@ -96,10 +110,29 @@ func (g generator) int(tag Tag) int64 {
 		return g.fromValues(tag.Values).(int64)
 	}
 
-	if tag.Type == "date" {
-		return g.dateUnix(tag)
+	if fn, ok := intTypes[tag.Type]; ok {
+		return fn(g, tag)
+	}
+	return g.length(tag)
+}
+
+// length return a random int64 in the inclusive bounds of tag
+func (g generator) length(tag Tag) int64 {
+	lo, hi := tag.intBounds()
+	return lo + int64(g.uint64n(uint64(hi)-uint64(lo)+1))
+}
+
+// uint64n return a random uint64 in [0, n), n == 0 means the full uint64 range
+func (g generator) uint64n(n uint64) uint64 {
+	if n&(n-1) == 0 {
+		return g.rand.Uint64() & (n - 1)
+	}
+	limit := math.MaxUint64 - math.MaxUint64%n
+	for {
+		if v := g.rand.Uint64(); v < limit {
+			return v % n
+		}
 	}
-	return g.int63n(tag.Max-tag.Min) + tag.Min
 }
 
 func (g generator) uint(tag Tag) uint64 {
@@ -107,7 +140,12 @@ func (g generator) uint(tag Tag) uint64 {
 		return g.fromValues(tag.Values).(uint64)
 	}
 
-	return g.rand.Uint64()%uint64(tag.Max-tag.Min) + uint64(tag.Min)
+	if fn, ok := intTypes[tag.Type]; ok {
+		return uint64(fn(g, tag))
+	}
+
+	lo, hi := tag.uintBounds()
+	return lo + g.uint64n(hi-lo+1)
 }
 
 func (g generator) float(tag Tag) float64 {
@@ -115,7 +153,75 @@ func (g generator) float(tag Tag) float64 {
 		return g.fromValues(tag.Values).(float64)
 	}
 
-	return g.rand.Float64()*float64(tag.Max-tag.Min) + float64(tag.Min)
+	if fn, ok := floatTypes[tag.Type]; ok {
+		return fn(g, tag)
+	}
+
+	min, max := tag.floatBounds()
+	if tag.Step > 0 {
+		return g.floatStep(tag, min, max)
+	}
+	return g.floatIn(tag, min, max)
+}
+
+// floatIn pick a random float in the bounds uniformly
+func (g generator) floatIn(tag Tag, min, max float64) float64 {
+	var u float64
+	for {
+		if tag.MaxInclusive {
+			u = float64(g.rand.Int63n(1<<53+1)) / (1 << 53)
+		} else {
+			u = g.rand.Float64()
+		}
+		if !tag.MinExclusive || u > 0 || min == max {
+			break
+		}
+	}
+	// avoid overflow of max-min when the range spans the whole float64
+	v := min*(1-u) + max*u
+	if !tag.MaxInclusive && v >= max && max > min {
+		v = math.Nextafter(max, min)
+	}
+	return v
+}
+
+// floatStep pick a random multiple of tag.Step in the bounds
+func (g generator) floatStep(tag Tag, min, max float64) float64 {
+	const eps = 1e-9
+	lo := math.Ceil(min/tag.Step - eps)
+	if tag.MinExclusive && math.Abs(lo*tag.Step-min) < eps*tag.Step {
+		lo++
+	}
+	hi := math.Floor(max/tag.Step + eps)
+	if !tag.MaxInclusive && math.Abs(hi*tag.Step-max) < eps*tag.Step && max > min {
+		hi--
+	}
+	if hi < lo {
+		hi = lo
+	}
+	if hi-lo < 1<<62 {
+		return roundStep(tag, lo+float64(g.int63n(int64(hi-lo)+1)))
+	}
+
+	// the steps overflow int63n when the range is open or wide,
+	// round a random float in the bounds to the nearest step instead
+	v := g.floatIn(tag, min, max)
+	k := math.Round(v / tag.Step)
+	if math.IsInf(k, 0) || math.Abs(k) >= 1<<53 {
+		// the floats around v are coarser than the step
+		return v
+	}
+	return roundStep(tag, math.Max(lo, math.Min(hi, k)))
+}
+
+// roundStep return k steps rounded to tag.Precision, or unrounded if the rounding overflows
+func roundStep(tag Tag, k float64) float64 {
+	v := k * tag.Step
+	p := math.Pow10(tag.Precision)
+	if r := math.Round(v*p) / p; !math.IsInf(r, 0) && !math.IsNaN(r) {
+		return r
+	}
+	return v
 }
 
 func (g generator) string(tag Tag) string {
@@ -123,28 +229,37 @@ func (g generator) string(tag Tag) string {
 		return g.fromValues(tag.Values).(string)
 	}
 
-	if isInTypeList(tag.Type) {
-		switch tag.Type {
-		case "date":
-			return g.dateString(tag)
-		case "email":
-			return g.eamil()
-		case "phone":
-			return g.phone()
-		case "url":
-			return g.url()
-		case "ipv4":
-			return g.ipv4()
-		case "domain":
-			return g.domain()
-		case "word":
-			return g.word(tag.Min, tag.Max)
-		case "sentence":
-			return g.sentence(tag.Min, tag.Max)
+	if tag.tmpl != nil {
+		return g.tmpl(tag.tmpl)
+	}
+
+	if tag.pattern != nil {
+		var b strings.Builder
+		g.pattern(&b, tag.pattern)
+		return b.String()
+	}
+
+	if p, ok := g.types[tag.Type]; ok {
+		return fmt.Sprint(g.provide(p, tag, reflect.String))
+	}
+	if fn, ok := stringTypes[tag.Type]; ok {
+		return fn(g, tag)
+	}
+
+	if len(tag.scripts) > 0 {
+		return g.runes(tag, g.length(tag))
+	}
+
+	if tag.Charset != "" {
+		chars := []rune(tag.Charset)
+		b := make([]rune, g.length(tag))
+		for i := range b {
+			b[i] = chars[g.int63n(int64(len(chars)))]
 		}
+		return string(b)
 	}
 
-	b := make([]byte, g.int63n(tag.Max-tag.Min)+tag.Min)
+	b := make([]byte, g.length(tag))
 	for i := range b {
 		b[i] = Chars[g.int63n(int64(len(Chars)))]
 	}
@@ -152,7 +267,7 @@ func (g generator) string(tag Tag) string {
 }
 
 func (g generator) eamil() string {
-	return g.word(0, 10) + "@" + g.word(0, 10) + "." + g.word(0, 10)
+	return g.word(1, 10) + "@" + g.word(1, 10) + "." + g.word(2, 10)
 }
 
 func (g generator) dateString(tag Tag) string {
@@ -160,17 +275,17 @@ func (g generator) dateString(tag Tag) string {
 	if tag.Format != "" {
 		format = tag.Format
 	}
-	return time.Now().Format(format)
+	return g.now().Format(format)
 }
 
 func (g generator) dateUnix(tag Tag) int64 {
 	switch tag.Format {
 	case "ns":
-		return time.Now().UnixNano()
+		return g.now().UnixNano()
 	case "ms":
-		return time.Now().UnixNano() / 1000000
+		return g.now().UnixNano() / 1000000
 	default:
-		return time.Now().Unix()
+		return g.now().Unix()
 	}
 }
 
@@ -204,11 +319,123 @@ func (g generator) word(min, max int64) string {
 	return string(b)
 }
 
-func (g generator) sentence(min, max int64) string {
+func (g generator) sentence(min, max int64, word func() string) string {
 	words := make([]string, g.int63n(max-min)+min)
 	for i := range words {
-		words[i] = g.word(1, 10)
+		words[i] = word()
+	}
+	if len(words) > 0 {
+		words[0] = strings.Title(words[0])
 	}
-	words[0] = strings.Title(words[0])
 	return strings.Join(words, " ") + "."
 }
+
+// PatternRepeat is the max extra repeat count of unbounded regular expression repeats
+const PatternRepeat = 10
+
+// pattern write a random string matching re into b
+func (g generator) pattern(b *strings.Builder, re *syntax.Regexp) {
+	switch re.Op {
+	case syntax.OpLiteral:
+		for _, r := range re.Rune {
+			if re.Flags&syntax.FoldCase != 0 && g.int63n(2) == 0 {
+				r = unicode.SimpleFold(r)
+			}
+			b.WriteRune(r)
+		}
+	case syntax.OpCharClass:
+		b.WriteRune(g.runeFromClass(re.Rune))
+	case syntax.OpAnyCharNotNL, syntax.OpAnyChar:
+		b.WriteRune(rune(' ' + g.int63n('~'-' '+1)))
+	case syntax.OpCapture:
+		g.pattern(b, re.Sub[0])
+	case syntax.OpStar:
+		g.repeat(b, re.Sub[0], 0, -1)
+	case syntax.OpPlus:
+		g.repeat(b, re.Sub[0], 1, -1)
+	case syntax.OpQuest:
+		g.repeat(b, re.Sub[0], 0, 1)
+	case syntax.OpRepeat:
+		g.repeat(b, re.Sub[0], re.Min, re.Max)
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			g.pattern(b, sub)
+		}
+	case syntax.OpAlternate:
+		g.pattern(b, re.Sub[g.int63n(int64(len(re.Sub)))])
+	}
+	// empty matches, line and text anchors and word boundaries produce nothing
+}
+
+func (g generator) repeat(b *strings.Builder, re *syntax.Regexp, min, max int) {
+	if max < 0 {
+		max = min + PatternRepeat
+	}
+	n := min + int(g.int63n(int64(max-min+1)))
+	for i := 0; i < n; i++ {
+		g.pattern(b, re)
+	}
+}
+
+// runeFromClass pick a rune from the [lo, hi] pairs of a char class,
+// printable ascii is preferred so that negated classes stay readable
+func (g generator) runeFromClass(class []rune) rune {
+	var ascii []rune
+	for i := 0; i+1 < len(class); i += 2 {
+		lo, hi := class[i], class[i+1]
+		if lo < ' ' {
+			lo = ' '
+		}
+		if hi > '~' {
+			hi = '~'
+		}
+		if lo <= hi {
+			ascii = append(ascii, lo, hi)
+		}
+	}
+	if len(ascii) > 0 {
+		class = ascii
+	}
+
+	var total int64
+	for i := 0; i+1 < len(class); i += 2 {
+		total += int64(class[i+1]-class[i]) + 1
+	}
+	n := g.int63n(total)
+	for i := 0; i+1 < len(class); i += 2 {
+		size := int64(class[i+1]-class[i]) + 1
+		if n < size {
+			return class[i] + rune(n)
+		}
+		n -= size
+	}
+	return 0
+}
+
+// tmpl render the parsed tmpl, g.call is used for GenFunc placeholders
+func (g generator) tmpl(parts []tmplPart) string {
+	var b strings.Builder
+	for _, p := range parts {
+		var v interface{}
+		switch {
+		case p.tag != nil && p.str:
+			v = g.string(*p.tag)
+		case p.tag != nil:
+			v = g.int(*p.tag)
+		case p.name == "seq":
+			*g.seq++
+			v = *g.seq
+		case p.name != "" && g.call != nil:
+			v = g.call(p.name)
+		default:
+			b.WriteString(p.text)
+			continue
+		}
+		if p.format != "" {
+			fmt.Fprintf(&b, p.format, v)
+		} else {
+			fmt.Fprint(&b, v)
+		}
+	}
+	return b.String()
+}
@@ -2,4 +2,8 @@ module github.com/CJH9004/mock
 
 go 1.13
 
-require github.com/stretchr/testify v1.4.0
+require (
+	github.com/stretchr/testify v1.4.0
+	google.golang.org/protobuf v1.27.1
+	gopkg.in/yaml.v2 v2.2.2
+)
@@ -0,0 +1,145 @@
+package mock
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// idSizes contains the byte length of the binary identifier types
+var idSizes = map[string]int{
+	"uuid":     16,
+	"ulid":     16,
+	"objectid": 12,
+}
+
+// SnowflakeEpoch is the epoch of snowflake in milliseconds, default the twitter epoch
+var SnowflakeEpoch int64 = 1288834974657
+
+// crockford is the base32 alphabet of ulid
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// uuid return a random uuid of version 4, or a time-ordered uuid of version 7 if format is v7
+func (g generator) uuid(format string) []byte {
+	b := make([]byte, 16)
+	binary.BigEndian.PutUint64(b, g.rand.Uint64())
+	binary.BigEndian.PutUint64(b[8:], g.rand.Uint64())
+	version := byte(4)
+	if format == "v7" {
+		ms := uint64(g.now().UnixNano() / int64(time.Millisecond))
+		b[0], b[1], b[2], b[3], b[4], b[5] = byte(ms>>40), byte(ms>>32), byte(ms>>24), byte(ms>>16), byte(ms>>8), byte(ms)
+		version = 7
+	}
+	b[6] = b[6]&0x0f | version<<4
+	b[8] = b[8]&0x3f | 0x80
+	return b
+}
+
+// ulid return a ulid with 48 bits milliseconds timestamp and 80 bits randomness
+func (g generator) ulid() []byte {
+	b := make([]byte, 16)
+	ms := uint64(g.now().UnixNano() / int64(time.Millisecond))
+	binary.BigEndian.PutUint64(b[:8], ms<<16)
+	binary.BigEndian.PutUint16(b[6:], uint16(g.rand.Uint32()))
+	binary.BigEndian.PutUint64(b[8:], g.rand.Uint64())
+	return b
+}
+
+// objectID return a mongodb ObjectId with 4 bytes seconds timestamp, 5 random bytes and 3 bytes counter
+func (g generator) objectID() []byte {
+	b := make([]byte, 12)
+	binary.BigEndian.PutUint32(b, uint32(g.now().Unix()))
+	binary.BigEndian.PutUint64(b[4:], g.rand.Uint64())
+	return b
+}
+
+// snowflake return a id with 41 bits milliseconds since SnowflakeEpoch, 10 bits machine id and 12 bits sequence
+func (g generator) snowflake() int64 {
+	ms := g.now().UnixNano()/int64(time.Millisecond) - SnowflakeEpoch
+	return (ms&(1<<41-1))<<22 | g.int63n(1<<10)<<12 | g.int63n(1<<12)
+}
+
+// id return the binary form of the identifier types
+func (g generator) id(tag Tag) []byte {
+	switch tag.Type {
+	case "uuid":
+		return g.uuid(tag.Format)
+	case "ulid":
+		return g.ulid()
+	case "objectid":
+		return g.objectID()
+	}
+	return nil
+}
+
+// idString return the string form of the binary identifier types
+func idString(typ string, b []byte) string {
+	switch typ {
+	case "uuid":
+		h := hex.EncodeToString(b)
+		return h[:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:]
+	case "ulid":
+		// 128 bits in 26 base32 chars, the first char holds the highest 3 bits
+		hi, lo := binary.BigEndian.Uint64(b), binary.BigEndian.Uint64(b[8:])
+		s := make([]byte, 26)
+		for i := 25; i >= 0; i-- {
+			s[i] = crockford[lo&31]
+			lo = lo>>5 | hi<<59
+			hi >>= 5
+		}
+		return string(s)
+	}
+	return hex.EncodeToString(b)
+}
+
+// mockID mock the binary identifier types into [N]byte and []byte fields
+func (m *mocker) mockID(t Tag, v reflect.Value) {
+	if v.Type().Elem().Kind() != reflect.Uint8 {
+		m.err = NewConflictError("fieldType", v.Type().String(), "type", t.Type, t.Type+" need string or byte array")
+		return
+	}
+	b := m.gen.id(t)
+	if v.Kind() == reflect.Array {
+		if v.Len() != len(b) {
+			m.err = NewConflictError("fieldType", v.Type().String(), "type", t.Type, fmt.Sprintf("%s need [%d]byte", t.Type, len(b)))
+			return
+		}
+		reflect.Copy(v, reflect.ValueOf(b))
+		return
+	}
+	v.SetBytes(b)
+}
+
+var (
+	uuidRe     = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[1-8][0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	ulidRe     = regexp.MustCompile(`^[0-7][0-9A-HJKMNP-TV-Z]{25}$`)
+	objectIDRe = regexp.MustCompile(`^[0-9a-f]{24}$`)
+)
+
+// validID return why s is not a valid value of the identifier types, or "" if it is valid
+func validID(t Tag, s string) string {
+	ok := true
+	switch t.Type {
+	case "uuid":
+		s = strings.ToLower(s)
+		ok = uuidRe.MatchString(s) && (t.Format == "" || s[14:15] == strings.TrimPrefix(t.Format, "v"))
+	case "ulid":
+		ok = ulidRe.MatchString(strings.ToUpper(s))
+	case "objectid":
+		ok = objectIDRe.MatchString(strings.ToLower(s))
+	case "snowflake":
+		n, err := strconv.ParseInt(s, 10, 64)
+		ok = err == nil && n > 0
+	default:
+		return ""
+	}
+	if !ok {
+		return "not a valid " + t.Type
+	}
+	return ""
+}
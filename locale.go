@@ -0,0 +1,194 @@
+package mock
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// Locale contains the offline dictionaries and formats of a locale,
+// '#' in the formats is replaced by a random digit and %s by a name
+type Locale struct {
+	FirstNames      []string
+	LastNames       []string
+	LastNameFirst   bool // full name is last name followed by first name
+	NameSeparator   string
+	Words           []string
+	WordSeparator   string
+	SentenceEnd     string
+	Cities          []string
+	States          []string
+	Countries       []string
+	Streets         []string
+	StreetFormats   []string // e.g. "### %s"
+	PostalFormats   []string // e.g. "#####"
+	Companies       []string
+	CompanyFormats  []string // e.g. "%s GmbH"
+	JobTitles       []string
+	PhoneFormats    []string // e.g. "+49 30 ########"
+	EmailDomains    []string
+	Transliteration map[rune]string // used to build ascii email and username
+	Latitude        [2]float64      // bounds of latitude, default [-90, 90]
+	Longitude       [2]float64      // bounds of longitude, default [-180, 180]
+}
+
+// DefaultLocale is the locale of person and address types when no locale is specified
+const DefaultLocale = "en_US"
+
+// Locales contains the avaliable locales of locale tag func and Options.Locale
+var Locales = map[string]*Locale{
+	"en_US": enUS,
+	"zh_CN": zhCN,
+	"de_DE": deDE,
+}
+
+// parseLocale return the locale of name in Locales
+func parseLocale(name string) (*Locale, error) {
+	if l, ok := Locales[name]; ok && l != nil {
+		return l, nil
+	}
+	names := make([]string, 0, len(Locales))
+	for k := range Locales {
+		names = append(names, k)
+	}
+	return nil, NewParamError("locale", strings.Join(names, "/"), name)
+}
+
+// tagLocale return the locale of tag, default Locales[DefaultLocale]
+func tagLocale(tag Tag) *Locale {
+	if tag.locale != nil {
+		return tag.locale
+	}
+	if l := Locales[DefaultLocale]; l != nil {
+		return l
+	}
+	return enUS
+}
+
+func (g generator) pick(list []string) string {
+	if len(list) == 0 {
+		return ""
+	}
+	return list[g.int63n(int64(len(list)))]
+}
+
+// format replace '#' in f by random digits and %s by name
+func (g generator) format(f, name string) string {
+	f = strings.Replace(f, "%s", name, 1)
+	var b strings.Builder
+	for _, r := range f {
+		if r == '#' {
+			r = rune('0' + g.int63n(10))
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func (g generator) localePhone(l *Locale) string {
+	return g.format(g.pick(l.PhoneFormats), "")
+}
+
+func (g generator) localeEmail(l *Locale) string {
+	local := ascii(l, g.pick(l.FirstNames)) + "." + ascii(l, g.pick(l.LastNames))
+	if local == "." {
+		local = g.word(3, 10)
+	}
+	domain := g.pick(l.EmailDomains)
+	if domain == "" {
+		domain = g.word(1, 10) + "." + g.word(1, 10)
+	}
+	return local + "@" + domain
+}
+
+// localeWord pick a word with rune length in [min, max) from the dictionary,
+// fall back to random letters
+func (g generator) localeWord(l *Locale, min, max int64) string {
+	var words []string
+	for _, w := range l.Words {
+		if n := int64(len([]rune(w))); n >= min && n < max {
+			words = append(words, w)
+		}
+	}
+	if len(words) == 0 {
+		return g.word(min, max)
+	}
+	return g.pick(words)
+}
+
+func (g generator) localeSentence(l *Locale, min, max int64) string {
+	words := make([]string, g.int63n(max-min)+min)
+	for i := range words {
+		words[i] = g.pick(l.Words)
+	}
+	if len(words) > 0 {
+		words[0] = strings.Title(words[0])
+	}
+	end := l.SentenceEnd
+	if end == "" {
+		end = "."
+	}
+	return strings.Join(words, l.WordSeparator) + end
+}
+
+// ascii transliterate s to lower case ascii letters, runes without transliteration are dropped
+func ascii(l *Locale, s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case r < unicode.MaxASCII && unicode.IsLetter(r):
+			b.WriteRune(r)
+		case l.Transliteration[r] != "":
+			b.WriteString(l.Transliteration[r])
+		}
+	}
+	return b.String()
+}
+
+func (g generator) fullname(l *Locale) string {
+	if l.LastNameFirst {
+		return g.pick(l.LastNames) + l.NameSeparator + g.pick(l.FirstNames)
+	}
+	return g.pick(l.FirstNames) + l.NameSeparator + g.pick(l.LastNames)
+}
+
+func (g generator) username(l *Locale) string {
+	first, last := ascii(l, g.pick(l.FirstNames)), ascii(l, g.pick(l.LastNames))
+	if first == "" || last == "" {
+		first, last = g.word(3, 8), g.word(3, 8)
+	}
+	switch g.int63n(4) {
+	case 0:
+		return first + "." + last
+	case 1:
+		return first + "_" + last
+	case 2:
+		return first[:1] + last + g.format("##", "")
+	default:
+		return first + g.format("###", "")
+	}
+}
+
+func (g generator) street(l *Locale) string {
+	return g.format(g.pick(l.StreetFormats), g.pick(l.Streets))
+}
+
+func (g generator) zip(l *Locale) string {
+	return g.format(g.pick(l.PostalFormats), "")
+}
+
+func (g generator) company(l *Locale) string {
+	return g.format(g.pick(l.CompanyFormats), g.pick(l.Companies))
+}
+
+// coordinate return a random latitude or longitude in the bounds of l
+func (g generator) coordinate(l *Locale, typ string) float64 {
+	bounds, def := l.Latitude, [2]float64{-90, 90}
+	if typ == "longitude" {
+		bounds, def = l.Longitude, [2]float64{-180, 180}
+	}
+	if bounds == [2]float64{} {
+		bounds = def
+	}
+	return math.Round((bounds[0]+g.rand.Float64()*(bounds[1]-bounds[0]))*1e6) / 1e6
+}
@@ -0,0 +1,180 @@
+package mock
+
+var enUS = &Locale{
+	FirstNames: []string{
+		"James", "Mary", "John", "Patricia", "Robert", "Jennifer", "Michael", "Linda", "William", "Elizabeth",
+		"David", "Barbara", "Richard", "Susan", "Joseph", "Jessica", "Thomas", "Sarah", "Charles", "Karen",
+		"Christopher", "Nancy", "Daniel", "Lisa", "Matthew", "Betty", "Anthony", "Margaret", "Mark", "Sandra",
+		"Donald", "Ashley", "Steven", "Emily", "Paul", "Donna", "Andrew", "Michelle", "Joshua", "Carol",
+	},
+	LastNames: []string{
+		"Smith", "Johnson", "Williams", "Brown", "Jones", "Garcia", "Miller", "Davis", "Rodriguez", "Martinez",
+		"Hernandez", "Lopez", "Gonzalez", "Wilson", "Anderson", "Thomas", "Taylor", "Moore", "Jackson", "Martin",
+		"Lee", "Perez", "Thompson", "White", "Harris", "Sanchez", "Clark", "Ramirez", "Lewis", "Robinson",
+		"Walker", "Young", "Allen", "King", "Wright", "Scott", "Torres", "Nguyen", "Hill", "Flores",
+	},
+	NameSeparator: " ",
+	Words: []string{
+		"time", "person", "year", "way", "day", "thing", "man", "world", "life", "hand",
+		"part", "child", "eye", "woman", "place", "work", "week", "case", "point", "government",
+		"company", "number", "group", "problem", "fact", "good", "new", "first", "last", "long",
+		"great", "little", "own", "other", "old", "right", "big", "high", "different", "small",
+		"large", "next", "early", "young", "important", "few", "public", "bad", "same", "able",
+	},
+	WordSeparator: " ",
+	SentenceEnd:   ".",
+	Cities: []string{
+		"New York", "Los Angeles", "Chicago", "Houston", "Phoenix", "Philadelphia", "San Antonio", "San Diego",
+		"Dallas", "San Jose", "Austin", "Jacksonville", "Columbus", "Charlotte", "Indianapolis", "Seattle",
+		"Denver", "Boston", "Nashville", "Portland", "Las Vegas", "Detroit", "Memphis", "Baltimore",
+	},
+	Streets: []string{
+		"Main Street", "Oak Street", "Pine Street", "Maple Avenue", "Cedar Lane", "Elm Street", "Washington Avenue",
+		"Lake Street", "Hill Road", "Park Avenue", "Sunset Boulevard", "River Road", "Church Street", "Broadway",
+	},
+	States: []string{
+		"Alabama", "Alaska", "Arizona", "California", "Colorado", "Florida", "Georgia", "Illinois", "Massachusetts",
+		"Michigan", "Minnesota", "Nevada", "New Jersey", "New York", "North Carolina", "Ohio", "Oregon",
+		"Pennsylvania", "Tennessee", "Texas", "Utah", "Virginia", "Washington", "Wisconsin",
+	},
+	Countries: []string{
+		"United States", "Canada", "Mexico", "Brazil", "Argentina", "United Kingdom", "Ireland", "France",
+		"Germany", "Spain", "Italy", "Netherlands", "Sweden", "Norway", "Poland", "China", "Japan",
+		"South Korea", "India", "Australia", "New Zealand", "South Africa", "Egypt", "Nigeria",
+	},
+	StreetFormats: []string{"### %s", "#### %s", "## %s"},
+	PostalFormats: []string{"#####", "#####-####"},
+	Companies: []string{
+		"Acme", "Globex", "Initech", "Umbrella", "Stark", "Wayne", "Hooli", "Vandelay", "Pied Piper", "Soylent",
+		"Cyberdyne", "Tyrell", "Wonka", "Gringotts", "Oscorp", "Massive Dynamic",
+	},
+	CompanyFormats: []string{"%s Inc.", "%s LLC", "%s Corp.", "%s Group", "%s & Sons"},
+	JobTitles: []string{
+		"Software Engineer", "Product Manager", "Data Scientist", "Sales Representative", "Accountant",
+		"Marketing Manager", "Graphic Designer", "Nurse", "Teacher", "Mechanical Engineer", "Project Manager",
+		"Customer Service Representative", "Financial Analyst", "Operations Manager", "Chief Executive Officer",
+	},
+	Latitude:     [2]float64{24.5, 49.4},
+	Longitude:    [2]float64{-124.8, -66.9},
+	PhoneFormats: []string{"(###) ###-####", "###-###-####", "+1 ### ### ####"},
+	EmailDomains: []string{"gmail.com", "yahoo.com", "hotmail.com", "outlook.com", "example.com"},
+}
+
+var zhCN = &Locale{
+	FirstNames: []string{
+		"伟", "芳", "娜", "敏", "静", "丽", "强", "磊", "军", "洋",
+		"勇", "艳", "杰", "娟", "涛", "明", "超", "秀英", "霞", "平",
+		"刚", "桂英", "华", "建国", "建华", "志强", "海燕", "晓明", "子涵", "浩然",
+	},
+	LastNames: []string{
+		"王", "李", "张", "刘", "陈", "杨", "黄", "赵", "吴", "周",
+		"徐", "孙", "马", "朱", "胡", "郭", "何", "高", "林", "罗",
+	},
+	LastNameFirst: true,
+	Words: []string{
+		"我们", "时间", "工作", "学习", "生活", "朋友", "问题", "发展", "社会", "国家",
+		"经济", "文化", "技术", "市场", "城市", "今天", "明天", "非常", "重要", "需要",
+		"可以", "已经", "开始", "知道", "觉得", "一起", "中国", "世界", "公司", "东西",
+	},
+	SentenceEnd: "。",
+	Cities: []string{
+		"北京", "上海", "广州", "深圳", "杭州", "南京", "成都", "武汉", "西安", "重庆",
+		"天津", "苏州", "长沙", "郑州", "青岛", "沈阳", "大连", "厦门", "昆明", "合肥",
+	},
+	Streets: []string{
+		"中山路", "人民路", "解放路", "建设路", "和平路", "新华路", "长江路", "黄河路", "南京路", "北京路",
+		"文化路", "胜利路", "朝阳路", "光明路", "青年路",
+	},
+	States: []string{
+		"北京市", "上海市", "天津市", "重庆市", "河北省", "山西省", "辽宁省", "吉林省", "黑龙江省", "江苏省",
+		"浙江省", "安徽省", "福建省", "江西省", "山东省", "河南省", "湖北省", "湖南省", "广东省", "海南省",
+		"四川省", "贵州省", "云南省", "陕西省", "甘肃省",
+	},
+	Countries: []string{
+		"中国", "美国", "加拿大", "墨西哥", "巴西", "阿根廷", "英国", "爱尔兰", "法国", "德国",
+		"西班牙", "意大利", "荷兰", "瑞典", "挪威", "波兰", "日本", "韩国", "印度", "澳大利亚",
+		"新西兰", "南非", "埃及", "新加坡",
+	},
+	StreetFormats: []string{"%s##号", "%s###号", "%s#号"},
+	PostalFormats: []string{"######"},
+	Companies: []string{
+		"华夏", "东方", "长城", "泰和", "鼎盛", "恒通", "金源", "宏远", "新世纪", "天成",
+		"瑞丰", "永信", "中科", "海纳", "博雅",
+	},
+	CompanyFormats: []string{"%s科技有限公司", "%s网络科技有限公司", "%s信息技术有限公司", "%s集团", "%s贸易有限公司"},
+	JobTitles: []string{
+		"软件工程师", "产品经理", "数据分析师", "销售代表", "会计", "市场经理", "平面设计师", "护士", "教师",
+		"机械工程师", "项目经理", "客服专员", "财务分析师", "运营经理", "总经理",
+	},
+	Latitude:     [2]float64{18.2, 53.5},
+	Longitude:    [2]float64{73.5, 134.8},
+	PhoneFormats: []string{"13#########", "15#########", "18#########", "010-########", "021-########"},
+	EmailDomains: []string{"qq.com", "163.com", "126.com", "sina.com", "example.cn"},
+	Transliteration: map[rune]string{
+		'伟': "wei", '芳': "fang", '娜': "na", '敏': "min", '静': "jing", '丽': "li", '强': "qiang", '磊': "lei",
+		'军': "jun", '洋': "yang", '勇': "yong", '艳': "yan", '杰': "jie", '娟': "juan", '涛': "tao", '明': "ming",
+		'超': "chao", '秀': "xiu", '英': "ying", '霞': "xia", '平': "ping", '刚': "gang", '桂': "gui", '华': "hua",
+		'建': "jian", '国': "guo", '志': "zhi", '海': "hai", '燕': "yan", '晓': "xiao", '子': "zi", '涵': "han",
+		'浩': "hao", '然': "ran", '王': "wang", '李': "li", '张': "zhang", '刘': "liu", '陈': "chen", '杨': "yang",
+		'黄': "huang", '赵': "zhao", '吴': "wu", '周': "zhou", '徐': "xu", '孙': "sun", '马': "ma", '朱': "zhu",
+		'胡': "hu", '郭': "guo", '何': "he", '高': "gao", '林': "lin", '罗': "luo",
+	},
+}
+
+var deDE = &Locale{
+	FirstNames: []string{
+		"Maximilian", "Sophie", "Alexander", "Marie", "Paul", "Sophia", "Elias", "Emma", "Ben", "Hannah",
+		"Noah", "Mia", "Leon", "Emilia", "Louis", "Anna", "Jonas", "Lena", "Felix", "Lea",
+		"Lukas", "Clara", "Finn", "Johanna", "Jürgen", "Jörg", "Uwe", "Günter", "Ursula", "Käthe",
+	},
+	LastNames: []string{
+		"Müller", "Schmidt", "Schneider", "Fischer", "Weber", "Meyer", "Wagner", "Becker", "Schulz", "Hoffmann",
+		"Schäfer", "Koch", "Bauer", "Richter", "Klein", "Wolf", "Schröder", "Neumann", "Schwarz", "Zimmermann",
+		"Braun", "Krüger", "Hofmann", "Hartmann", "Lange", "Schmitt", "Werner", "Krause", "Meier", "Lehmann",
+	},
+	NameSeparator: " ",
+	Words: []string{
+		"Zeit", "Jahr", "Mensch", "Tag", "Welt", "Leben", "Hand", "Teil", "Kind", "Auge",
+		"Frau", "Mann", "Haus", "Arbeit", "Woche", "Stadt", "Land", "Weg", "Frage", "Schule",
+		"gut", "neu", "erste", "lang", "groß", "klein", "alt", "hoch", "wichtig", "schön",
+		"und", "oder", "aber", "nicht", "auch", "immer", "heute", "morgen", "zusammen", "über",
+	},
+	WordSeparator: " ",
+	SentenceEnd:   ".",
+	Cities: []string{
+		"Berlin", "Hamburg", "München", "Köln", "Frankfurt am Main", "Stuttgart", "Düsseldorf", "Leipzig",
+		"Dortmund", "Essen", "Bremen", "Dresden", "Hannover", "Nürnberg", "Duisburg", "Bochum",
+	},
+	Streets: []string{
+		"Hauptstraße", "Schulstraße", "Gartenstraße", "Bahnhofstraße", "Dorfstraße", "Bergstraße", "Kirchstraße",
+		"Waldstraße", "Ringstraße", "Lindenstraße", "Schillerstraße", "Goethestraße", "Am Markt", "Mühlenweg",
+	},
+	States: []string{
+		"Baden-Württemberg", "Bayern", "Berlin", "Brandenburg", "Bremen", "Hamburg", "Hessen",
+		"Mecklenburg-Vorpommern", "Niedersachsen", "Nordrhein-Westfalen", "Rheinland-Pfalz", "Saarland",
+		"Sachsen", "Sachsen-Anhalt", "Schleswig-Holstein", "Thüringen",
+	},
+	Countries: []string{
+		"Deutschland", "Österreich", "Schweiz", "Frankreich", "Italien", "Spanien", "Niederlande", "Belgien",
+		"Polen", "Tschechien", "Dänemark", "Schweden", "Norwegen", "Vereinigtes Königreich", "Irland",
+		"Vereinigte Staaten", "Kanada", "Brasilien", "China", "Japan", "Indien", "Australien", "Türkei", "Griechenland",
+	},
+	StreetFormats: []string{"%s ##", "%s #", "%s ##a"},
+	PostalFormats: []string{"#####"},
+	Companies: []string{
+		"Müller", "Schmidt", "Becker", "Weber", "Nordlicht", "Alpenblick", "Rheinland", "Elbtal", "Hansa", "Bavaria",
+	},
+	CompanyFormats: []string{"%s GmbH", "%s AG", "%s GmbH & Co. KG", "%s KG", "%s e.V."},
+	JobTitles: []string{
+		"Softwareentwickler", "Produktmanager", "Datenanalyst", "Vertriebsmitarbeiter", "Buchhalter",
+		"Marketingleiter", "Grafikdesigner", "Krankenpfleger", "Lehrer", "Maschinenbauingenieur", "Projektleiter",
+		"Kundenberater", "Finanzanalyst", "Betriebsleiter", "Geschäftsführer",
+	},
+	Latitude:     [2]float64{47.3, 55.0},
+	Longitude:    [2]float64{5.9, 15.0},
+	PhoneFormats: []string{"+49 30 ########", "+49 89 #######", "030 ########", "0151 ########", "0170 #######"},
+	EmailDomains: []string{"web.de", "gmx.de", "t-online.de", "freenet.de", "example.de"},
+	Transliteration: map[rune]string{
+		'ä': "ae", 'ö': "oe", 'ü': "ue", 'ß': "ss",
+	},
+}
@@ -3,8 +3,11 @@ package mock
 import (
 	"errors"
 	"fmt"
+	"math"
 	"math/rand"
 	"reflect"
+	"regexp"
+	"time"
 )
 
 // GenFunc is costomized mock func
@@ -27,30 +30,64 @@ type Mocker interface {
 	SetValidFuncs(fns ValidFuncs)
 	SetTags(map[string]string)
 	SetFormats(map[string]string)
+	SetCharsets(map[string]string)
+	SetLocale(string)
+	SetNow(func() time.Time)
+	RegisterType(name string, p TypeProvider)
+	RegisterTypeFunc(typ reflect.Type, fn TypeFunc)
+	RegisterEnum(typ reflect.Type, values ...interface{}) error
+	SetNameTag(string)
+	SetOmitEmpty(float64)
+	SetFields(map[string]string)
+	SetTagKey(string)
+	SetValidateTag(string)
+	For(data interface{}) *StructConfig
 	SetBefore(func(interface{}))
 	SetAfter(func(interface{}))
 }
 
 type mocker struct {
-	current    interface{}
-	genFuncs   GenFuncs
-	validFuncs ValidFuncs
-	after      func(interface{})
-	before     func(interface{})
-	tags       map[string]string
-	formats    map[string]string
-	gen        generator
-	err        error
+	current     interface{}
+	genFuncs    GenFuncs
+	validFuncs  ValidFuncs
+	after       func(interface{})
+	before      func(interface{})
+	tags        map[string]string
+	formats     map[string]string
+	charsets    map[string]string
+	locale      string
+	types       map[string]TypeProvider
+	typeFuncs   map[reflect.Type]TypeFunc
+	enums       map[reflect.Type][]reflect.Value
+	nameTag     string
+	omitEmpty   float64
+	fields      map[string]string
+	tagKey      string
+	validateTag string
+	structs     map[reflect.Type]*StructConfig
+	gen         generator
+	err         error
+	patterns    map[string]*regexp.Regexp
 }
 
 // Options store the ortions of Mocker
 type Options struct {
-	GenFuncs   GenFuncs
-	ValidFuncs ValidFuncs
-	Tags       map[string]string
-	Formats    map[string]string
-	After      func(interface{})
-	Before     func(interface{})
+	GenFuncs    GenFuncs
+	ValidFuncs  ValidFuncs
+	Tags        map[string]string
+	Formats     map[string]string
+	Charsets    map[string]string
+	Locale      string // default locale name in Locales
+	Types       map[string]TypeProvider
+	TypeFuncs   map[reflect.Type]TypeFunc
+	NameTag     string            // struct tag naming the fields in paths and Fields, e.g. json, skip fields tagged "-"
+	OmitEmpty   float64           // probability of leaving the omitempty fields of NameTag zero
+	Fields      map[string]string // tags overriding the mock tag of the fields by path, e.g. {"user.email": "type(email)"}
+	TagKey      string            // struct tag key of mock tags, default DefaultTagKey
+	ValidateTag string            // struct tag key of go-playground/validator rules used without mock tags, e.g. validate
+	Now         func() time.Time  // clock of date and time-ordered identifiers, default time.Now
+	After       func(interface{})
+	Before      func(interface{})
 }
 
 // New return a Mocker
@@ -58,15 +95,35 @@ func New(seed int64, options *Options) Mocker {
 	if options == nil {
 		options = &Options{}
 	}
-	return &mocker{
-		genFuncs:   options.GenFuncs,
-		validFuncs: options.ValidFuncs,
-		after:      options.After,
-		before:     options.Before,
-		tags:       options.Tags,
-		formats:    options.Formats,
-		gen:        generator{rand: rand.New(rand.NewSource(seed))},
+	m := &mocker{
+		genFuncs:    options.GenFuncs,
+		validFuncs:  options.ValidFuncs,
+		after:       options.After,
+		before:      options.Before,
+		tags:        options.Tags,
+		formats:     options.Formats,
+		charsets:    options.Charsets,
+		locale:      options.Locale,
+		nameTag:     options.NameTag,
+		omitEmpty:   options.OmitEmpty,
+		fields:      options.Fields,
+		tagKey:      options.TagKey,
+		validateTag: options.ValidateTag,
+		gen:         newGenerator(rand.New(rand.NewSource(seed))),
 	}
+	if options.Now != nil {
+		m.gen.now = options.Now
+	}
+	m.gen.call = func(name string) interface{} {
+		return m.genFuncs[name](m.current)
+	}
+	for name, p := range options.Types {
+		m.RegisterType(name, p)
+	}
+	for typ, fn := range options.TypeFuncs {
+		m.RegisterTypeFunc(typ, fn)
+	}
+	return m
 }
 
 func (m *mocker) SetGenFuncs(fns GenFuncs) {
@@ -85,6 +142,18 @@ func (m *mocker) SetFormats(formats map[string]string) {
 	m.formats = formats
 }
 
+func (m *mocker) SetCharsets(charsets map[string]string) {
+	m.charsets = charsets
+}
+
+func (m *mocker) SetLocale(locale string) {
+	m.locale = locale
+}
+
+func (m *mocker) SetNow(now func() time.Time) {
+	m.gen.now = now
+}
+
 func (m *mocker) SetAfter(fn func(interface{})) {
 	m.after = fn
 }
@@ -99,11 +168,12 @@ func (m *mocker) Mock(tags string, data interface{}) error {
 	}
 
 	m.current = data
+	m.err = nil
 	v := reflect.ValueOf(data)
 	if v.Kind() != reflect.Ptr {
 		return errors.New("not a pointer")
 	}
-	m.mock(tags, v.Elem())
+	m.mock("", tags, v.Elem())
 
 	if m.after != nil {
 		m.after(m.current)
@@ -111,28 +181,59 @@ func (m *mocker) Mock(tags string, data interface{}) error {
 	return m.err
 }
 
-func (m *mocker) Valid(tags string, data interface{}) (bool, error) {
-	return true, nil
-}
-
-func (m *mocker) mock(tags string, v reflect.Value) {
+func (m *mocker) mock(path, tags string, v reflect.Value) {
 	if v.Type().Kind() == reflect.Ptr {
-		m.mock(tags, v.Elem())
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		m.mock(path, tags, v.Elem())
+		return
+	}
+	if m.isCustom(v) {
+		m.mockCustom(path, tags, v)
+		return
+	}
+	if m.mockEnum(v) {
+		return
+	}
+	if v.CanAddr() && isUnmarshaler(v.Type()) && m.mockUnmarshaler(tags, v) {
+		return
 	}
-	t := m.parseTag(v.Type().Name(), tags)
+	typ, isNet := netTypes[v.Type()]
+	if !isNet {
+		typ = v.Kind().String()
+	}
+	t := m.parseTag(typ, tags)
 	if fn, ok := m.genFuncs[t.GenFunc]; ok {
 		v.Set(reflect.ValueOf(fn(m.current)))
 		return
 	}
+	if p, ok := m.types[t.Type]; ok {
+		m.mockType(p, t, v)
+		return
+	}
+	if isNet {
+		m.mockNet(t, v)
+		return
+	}
+	if _, ok := idSizes[t.Type]; ok && v.Kind() != reflect.String {
+		m.mockID(t, v)
+		return
+	}
+	if blobTypes[t.Type] && v.Kind() != reflect.String ||
+		t.Type == "" && t.Elem == "" && v.Kind() == reflect.Slice && isBytes(v) {
+		m.mockBlob(t, v)
+		return
+	}
 	switch v.Type().Kind() {
 	case reflect.Struct:
-		m.mockStruct(t, v)
+		m.mockStruct(path, v)
 	case reflect.Slice:
-		m.mockSlice(t, v)
+		m.mockSlice(path, t, v)
 	case reflect.Array:
-		m.mockArray(t, v)
+		m.mockArray(path, t, v)
 	case reflect.Map:
-		m.mockMap(t, v)
+		m.mockMap(path, t, v)
 	default:
 		m.mockField(t, v)
 	}
@@ -141,7 +242,8 @@ func (m *mocker) mock(tags string, v reflect.Value) {
 func (m *mocker) parseTag(typ, tags string) Tag {
 	var t Tag
 	var err error
-	if t, err = ParseTag(typ, tags); err != nil {
+	ctx := tagContext{genFuncs: m.genFuncs, charsets: m.charsets, locale: m.locale, types: m.types}
+	if t, err = parseTag(typ, tags, ctx); err != nil {
 		m.err = err
 	}
 	if tag, ok := m.tags[t.Tag]; ok {
@@ -159,16 +261,26 @@ func (m *mocker) parseTag(typ, tags string) Tag {
 	return t
 }
 
-func (m *mocker) mockStruct(tag Tag, v reflect.Value) {
+func (m *mocker) mockStruct(path string, v reflect.Value) {
 	t := v.Type()
+	s := m.structConfig(t)
 	for i := 0; i < v.NumField(); i++ {
 		vf := v.Field(i)
 		tf := t.Field(i)
-		tags := tf.Tag.Get("mock")
-		if !v.Field(i).CanSet() || tags == "-" || !vf.IsZero() {
+		name, omitempty, skip := m.fieldName(tf)
+		name = joinPath(path, name)
+		tags := m.fieldTags(name, s, tf)
+		if !v.Field(i).CanSet() || skip || tags == "-" || !vf.IsZero() {
+			continue
+		}
+		if omitempty && m.omitEmpty > 0 && m.gen.rand.Float64() < m.omitEmpty {
+			continue
+		}
+		if f, ok := s.field(tf.Name); ok && f.unique {
+			m.mockUnique(f, name, tags, vf)
 			continue
 		}
-		m.mock(tags, vf)
+		m.mock(name, tags, vf)
 	}
 }
 
@@ -208,55 +320,98 @@ func (m *mocker) mockField(t Tag, v reflect.Value) {
 }
 
 func (m *mocker) mockString(t Tag, v reflect.Value) {
-	v.SetString(m.gen.string(t))
+	s := m.gen.string(t)
+	if t.typeLength() {
+		// the type ignores the range, regenerate the strings out of the length bounds
+		lo, hi := t.intBounds()
+		for i := 0; !inLength(s, lo, hi); i++ {
+			if i == UniqueRetry {
+				m.err = NewConflictError("type", t.Type, "range", fmt.Sprintf("[%d, %d]", lo, hi),
+					fmt.Sprintf("no %s in the length range in %d retries", t.Type, UniqueRetry))
+				return
+			}
+			s = m.gen.string(t)
+		}
+	}
+	v.SetString(s)
 }
 
+// mockInt clamp the generated value to the bounds of the field type
 func (m *mocker) mockInt(t Tag, v reflect.Value) {
-	v.SetInt(m.gen.int(t))
+	n := m.gen.int(t)
+	if v.OverflowInt(n) {
+		bits := uint(v.Type().Bits())
+		if n < 0 {
+			n = math.MinInt64 >> (64 - bits)
+		} else {
+			n = math.MaxInt64 >> (64 - bits)
+		}
+	}
+	v.SetInt(n)
 }
 
+// mockUint clamp the generated value to the bounds of the field type
 func (m *mocker) mockUint(t Tag, v reflect.Value) {
-	v.SetUint(m.gen.uint(t))
+	n := m.gen.uint(t)
+	if v.OverflowUint(n) {
+		n = math.MaxUint64 >> (64 - uint(v.Type().Bits()))
+	}
+	v.SetUint(n)
 }
 
+// mockFloat clamp the generated value to the bounds of the field type
 func (m *mocker) mockFloat(t Tag, v reflect.Value) {
-	v.SetFloat(m.gen.float(t))
+	n := m.gen.float(t)
+	if v.OverflowFloat(n) {
+		n = math.Copysign(math.MaxFloat32, n)
+	}
+	v.SetFloat(n)
 }
 
 func (m *mocker) mockBool(t Tag, v reflect.Value) {
 	v.SetBool(m.gen.bool(t))
 }
 
-func (m *mocker) mockSlice(t Tag, v reflect.Value) {
-	length := m.gen.int(t)
+func (m *mocker) mockSlice(path string, t Tag, v reflect.Value) {
+	length := m.gen.length(t)
 	v.Set(reflect.MakeSlice(v.Type(), int(length), int(length)))
 	for i := 0; i < v.Len(); i++ {
-		m.mock(t.Elem, v.Index(i))
+		m.mock(fmt.Sprintf("%s[%d]", path, i), t.Elem, v.Index(i))
 	}
 }
 
-func (m *mocker) mockArray(t Tag, v reflect.Value) {
+func (m *mocker) mockArray(path string, t Tag, v reflect.Value) {
 	for i := 0; i < v.Len(); i++ {
-		m.mock(t.Elem, v.Index(i))
+		m.mock(fmt.Sprintf("%s[%d]", path, i), t.Elem, v.Index(i))
 	}
 }
 
-func (m *mocker) mockMap(t Tag, v reflect.Value) {
+func (m *mocker) mockMap(path string, t Tag, v reflect.Value) {
 	if v.Type().Key().Kind() != reflect.String {
 		m.err = fmt.Errorf("Unsupported map key type: %s", v.Type().Key().Kind())
 		return
 	}
 
-	length := m.gen.int(t)
-	v.Set(reflect.MakeMapWithSize(v.Type(), int(length)))
-	for i := 0; i < int(length); i++ {
+	length := int(m.gen.length(t))
+	v.Set(reflect.MakeMapWithSize(v.Type(), length))
+	// stop at the errors of the entries only, the earlier error is kept
+	prev := m.err
+	m.err = nil
+	defer func() {
+		if prev != nil {
+			m.err = prev
+		}
+	}()
+	// regenerate the duplicate keys, at most UniqueRetry times per entry
+	for i := 0; v.Len() < length && i < length*UniqueRetry && m.err == nil; i++ {
 		keyTag := "type(word)"
 		if t.Key != "" {
 			keyTag = t.Key
 		}
-		key := reflect.ValueOf(m.gen.string(m.parseTag("string", keyTag)))
+		key := reflect.New(v.Type().Key()).Elem()
+		m.mock(path, keyTag, key)
 		value := reflect.New(v.Type().Elem())
-		m.mock(t.Elem, value.Elem())
+		m.mock(fmt.Sprintf("%s[%v]", path, key), t.Elem, value.Elem())
 		v.SetMapIndex(key, value.Elem())
 	}
 }
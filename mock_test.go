@@ -1,10 +1,22 @@
 package mock
 
 import (
+	"bytes"
+	"database/sql"
 	"fmt"
+	"image/png"
+	"math"
+	"math/big"
+	"math/rand"
+	"net"
+	"net/http"
+	"reflect"
+	"regexp"
 	"strings"
 	"testing"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -152,6 +164,12 @@ func TestMockMap(t *testing.T) {
 			assert.True(t, v < 10)
 		}
 	}
+
+	// the duplicate keys are regenerated
+	var keys map[string]int
+	err = m.Mock("range([5, 5]) key(value(a, b, c, d, e))", &keys)
+	assert.Nil(t, err)
+	assert.Len(t, keys, 5)
 }
 
 func TestMockArray(t *testing.T) {
@@ -301,3 +319,1193 @@ func TestMockBool(t *testing.T) {
 	err = m.Mock("", &n3)
 	assert.Nil(t, err)
 }
+
+func TestMockFloatRange(t *testing.T) {
+	m := New(time.Now().UnixNano(), nil)
+	var err error
+	count := 100
+
+	var n float64
+	for i := 0; i < count; i++ {
+		n = 0
+		err = m.Mock("range([0.01, 99.99]) precision(2)", &n)
+		assert.Nil(t, err)
+		assert.True(t, n >= 0.01)
+		assert.True(t, n <= 99.99)
+		assert.Equal(t, math.Round(n*100)/100, n)
+	}
+
+	for i := 0; i < count; i++ {
+		n = 0
+		err = m.Mock("range(]0, 0.1]) step(0.05)", &n)
+		assert.Nil(t, err)
+		assert.Contains(t, []float64{0.05, 0.1}, n)
+	}
+
+	// the steps of open and huge ranges overflow int63
+	for i := 0; i < count; i++ {
+		n = 0
+		assert.NotPanics(t, func() { err = m.Mock("range(0,) precision(2)", &n) })
+		assert.Nil(t, err)
+		assert.True(t, n >= 0)
+
+		n = 0
+		assert.NotPanics(t, func() { err = m.Mock("range(,5) step(0.5)", &n) })
+		assert.Nil(t, err)
+		assert.True(t, n < 5)
+		assert.Equal(t, 0.0, math.Mod(n, 0.5))
+
+		n = 0
+		assert.NotPanics(t, func() { err = m.Mock("range(0, 1e300) precision(2)", &n) })
+		assert.Nil(t, err)
+		assert.True(t, n >= 0 && n < 1e300)
+	}
+
+	var n32 float32
+	err = m.Mock("range(-0.5, 0.5)", &n32)
+	assert.Nil(t, err)
+	assert.True(t, n32 >= -0.5)
+	assert.True(t, n32 < 0.5)
+
+	var i int
+	for j := 0; j < count; j++ {
+		i = 0
+		err = m.Mock("range(]1, 3])", &i)
+		assert.Nil(t, err)
+		assert.Contains(t, []int{2, 3}, i)
+	}
+
+	i = 0
+	err = m.Mock("precision(2)", &i)
+	assert.NotNil(t, err)
+}
+
+func TestMockFullWidthRange(t *testing.T) {
+	m := New(time.Now().UnixNano(), nil)
+	var err error
+	count := 100
+
+	var n int64
+	err = m.Mock("range(-9223372036854775808, 9223372036854775807])", &n)
+	assert.Nil(t, err)
+
+	var u uint64
+	for i := 0; i < count; i++ {
+		u = 0
+		err = m.Mock("range(18446744073709551614,)", &u)
+		assert.Nil(t, err)
+		assert.True(t, u >= 18446744073709551614)
+	}
+
+	var n8 int8
+	for i := 0; i < count; i++ {
+		n8 = 0
+		err = m.Mock("range(100, 300)", &n8)
+		assert.Nil(t, err)
+		assert.True(t, n8 >= 100)
+	}
+
+	for i := 0; i < count; i++ {
+		n8 = 0
+		err = m.Mock("range(,-100)", &n8)
+		assert.Nil(t, err)
+		assert.True(t, n8 < -100)
+	}
+
+	var u8 uint8
+	for i := 0; i < count; i++ {
+		u8 = 0
+		err = m.Mock("range(250,)", &u8)
+		assert.Nil(t, err)
+		assert.True(t, u8 >= 250)
+	}
+}
+
+func TestMockOverflow(t *testing.T) {
+	var err error
+
+	var n8 int8
+	err = New(time.Now().UnixNano(), nil).Mock("range(200, 300)", &n8)
+	assert.NotNil(t, err)
+
+	var u8 uint8
+	err = New(time.Now().UnixNano(), nil).Mock("range(-10, -1)", &u8)
+	assert.NotNil(t, err)
+
+	err = New(time.Now().UnixNano(), nil).Mock("value(1, 300)", &u8)
+	assert.NotNil(t, err)
+
+	var f32 float32
+	err = New(time.Now().UnixNano(), nil).Mock("range(1e39, 1e40)", &f32)
+	assert.NotNil(t, err)
+
+	m := New(time.Now().UnixNano(), nil)
+	hit := false
+	// the range is clamped to [0, 255], 255 is hit with the probability 1/256
+	for i := 0; i < 10000 && !hit; i++ {
+		u8 = 0
+		err = m.Mock("range(-10, 1000)", &u8)
+		assert.Nil(t, err)
+		hit = hit || u8 == math.MaxUint8
+	}
+	assert.True(t, hit)
+}
+
+func TestMockPattern(t *testing.T) {
+	m := New(time.Now().UnixNano(), nil)
+	var err error
+	count := 100
+
+	type N struct {
+		SKU   string `mock:"pattern([A-Z]{3}-\\d{4})"`
+		Plate string `mock:"pattern((京|沪)[A-Z]·[0-9A-Z]{5})"`
+		Name  string `mock:"pattern((?i)go(pher)?s?) range(1)"`
+	}
+	sku := regexp.MustCompile(`^[A-Z]{3}-\d{4}$`)
+	plate := regexp.MustCompile(`^(京|沪)[A-Z]·[0-9A-Z]{5}$`)
+	name := regexp.MustCompile(`^(?i)go(pher)?s?$`)
+	for i := 0; i < count; i++ {
+		n := N{}
+		err = m.Mock("", &n)
+		assert.Nil(t, err)
+		assert.Regexp(t, sku, n.SKU)
+		assert.Regexp(t, plate, n.Plate)
+		assert.Regexp(t, name, n.Name)
+		ok, err := m.Valid("", n)
+		assert.True(t, ok)
+		assert.Nil(t, err)
+	}
+
+	var n int
+	err = m.Mock("pattern(\\d+)", &n)
+	assert.NotNil(t, err)
+
+	var s string
+	err = m.Mock("pattern([a-z)", &s)
+	assert.NotNil(t, err)
+}
+
+func TestMockTemplate(t *testing.T) {
+	m := New(time.Now().UnixNano(), nil)
+	m.SetGenFuncs(GenFuncs{
+		"region": func(data interface{}) interface{} { return "EU" },
+	})
+	var err error
+
+	var n string
+	err = m.Mock("tmpl({{word}}-{{range(100,999)}}@{{domain}})", &n)
+	assert.Nil(t, err)
+	assert.Regexp(t, `^[a-z]+-[1-9]\d\d@www\.[a-z]+\.[a-z]+$`, n)
+
+	date := time.Now().Format("20060102")
+	for i := 1; i <= 3; i++ {
+		err = m.Mock("tmpl(ORD-{{date|20060102}}-{{seq|%04d}}-{{region}})", &n)
+		assert.Nil(t, err)
+		assert.Equal(t, fmt.Sprintf("ORD-%s-%04d-EU", date, i), n)
+	}
+
+	err = m.Mock("tmpl({{pattern([A-Z]{2})}}{{value(x, y)}})", &n)
+	assert.Nil(t, err)
+	assert.Regexp(t, `^[A-Z]{2}[xy]$`, n)
+
+	// GenFunc placeholders in the tags of keys and elements
+	var keys map[string]string
+	err = m.Mock("range(1, 1]) key(tmpl(K-{{region}})) elem(tmpl({{region}}))", &keys)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"K-EU": "EU"}, keys)
+
+	err = m.Mock("tmpl({{unknown}})", &n)
+	assert.NotNil(t, err)
+	_, err = NewGen(rand.New(rand.NewSource(1))).String("tmpl({{region}})")
+	assert.NotNil(t, err)
+
+	err = m.Mock("tmpl({{word)", &n)
+	assert.NotNil(t, err)
+}
+
+func TestMockCharset(t *testing.T) {
+	m := New(time.Now().UnixNano(), &Options{
+		Charsets: map[string]string{"vowel": "aeiou"},
+	})
+	var err error
+	count := 10
+
+	var n string
+	for i := 0; i < count; i++ {
+		err = m.Mock("charset(hex) range(32, 32)", &n)
+		assert.Nil(t, err)
+		assert.Regexp(t, `^[0-9a-f]{32}$`, n)
+
+		err = m.Mock("charset(vowel)", &n)
+		assert.Nil(t, err)
+		assert.Regexp(t, `^[aeiou]+$`, n)
+
+		err = m.Mock(`charset("αβγ") range(5, 5)`, &n)
+		assert.Nil(t, err)
+		assert.Regexp(t, `^[αβγ]{5}$`, n)
+	}
+
+	err = m.Mock("charset(unknown)", &n)
+	assert.NotNil(t, err)
+
+	ok, err := m.Valid("charset(digits)", "12a")
+	assert.False(t, ok)
+	assert.IsType(t, InvalidError{}, err)
+
+	ok, err = m.Valid("charset(vowel)", "aei")
+	assert.True(t, ok)
+	assert.Nil(t, err)
+}
+
+func TestMockScript(t *testing.T) {
+	m := New(time.Now().UnixNano(), nil)
+	var err error
+	count := 10
+
+	var n string
+	for i := 0; i < count; i++ {
+		err = m.Mock("script(Han) range(5, 5)", &n)
+		assert.Nil(t, err)
+		assert.Equal(t, 5, len([]rune(n)))
+		for _, r := range n {
+			assert.True(t, unicode.Is(unicode.Han, r))
+		}
+
+		err = m.Mock("type(word) script(Arabic|Emoji, marks, edge) range([20, 20])", &n)
+		assert.Nil(t, err)
+		assert.True(t, utf8.ValidString(n))
+		assert.Equal(t, 20, utf8.RuneCountInString(n))
+		ok, err := m.Valid("script(Arabic|Emoji, marks, edge)", n)
+		assert.True(t, ok)
+		assert.Nil(t, err)
+
+		err = m.Mock("type(sentence) script(Latin) range(3, 3)", &n)
+		assert.Nil(t, err)
+		assert.Equal(t, 3, len(strings.Fields(n)))
+	}
+
+	ok, err := m.Valid("script(Han)", "abc")
+	assert.False(t, ok)
+	assert.IsType(t, InvalidError{}, err)
+
+	err = m.Mock("script(Klingon)", &n)
+	assert.NotNil(t, err)
+}
+
+func TestMockLocale(t *testing.T) {
+	m := New(time.Now().UnixNano(), &Options{Locale: "de_DE"})
+	var err error
+	count := 10
+
+	type N struct {
+		Phone    string `mock:"type(phone)"`
+		Email    string `mock:"type(email)"`
+		Word     string `mock:"type(word)"`
+		Sentence string `mock:"type(sentence) range(3, 3)"`
+		ZhPhone  string `mock:"type(phone) locale(zh_CN)"`
+		ZhEmail  string `mock:"type(email) locale(zh_CN)"`
+	}
+	for i := 0; i < count; i++ {
+		n := N{}
+		err = m.Mock("", &n)
+		assert.Nil(t, err)
+		assert.Regexp(t, `^(\+49 )?0?[0-9]{2,3} [0-9]{7,8}$`, n.Phone)
+		assert.Regexp(t, `^[a-z]+\.[a-z]+@[a-z-]+\.de$`, n.Email)
+		assert.Contains(t, deDE.Words, n.Word)
+		assert.Equal(t, 3, len(strings.Fields(n.Sentence)))
+		assert.Regexp(t, `^(1[358][0-9]{9}|0[12][0-9]-[0-9]{8})$`, n.ZhPhone)
+		assert.Regexp(t, `^[a-z]+\.[a-z]+@[a-z0-9]+\.(com|cn)$`, n.ZhEmail)
+	}
+
+	// seed-deterministic
+	var a, b N
+	assert.Nil(t, New(42, &Options{Locale: "en_US"}).Mock("", &a))
+	assert.Nil(t, New(42, &Options{Locale: "en_US"}).Mock("", &b))
+	assert.Equal(t, a, b)
+
+	var n string
+	err = m.Mock("type(phone) locale(xx_XX)", &n)
+	assert.NotNil(t, err)
+}
+
+func TestMockPerson(t *testing.T) {
+	m := New(time.Now().UnixNano(), nil)
+	var err error
+	count := 10
+
+	type Person struct {
+		FirstName string  `mock:"type(firstname)"`
+		LastName  string  `mock:"type(lastname)"`
+		FullName  string  `mock:"type(fullname) locale(zh_CN)"`
+		Username  string  `mock:"type(username) locale(de_DE)"`
+		Street    string  `mock:"type(street)"`
+		City      string  `mock:"type(city) locale(de_DE)"`
+		State     string  `mock:"type(state)"`
+		Zip       string  `mock:"type(zip) locale(zh_CN)"`
+		Country   string  `mock:"type(country)"`
+		Lat       float64 `mock:"type(latitude) locale(de_DE)"`
+		Lng       string  `mock:"type(longitude)"`
+		Company   string  `mock:"type(company)"`
+		JobTitle  string  `mock:"type(jobtitle)"`
+	}
+	for i := 0; i < count; i++ {
+		p := Person{}
+		err = m.Mock("", &p)
+		assert.Nil(t, err)
+		assert.Contains(t, enUS.FirstNames, p.FirstName)
+		assert.Contains(t, enUS.LastNames, p.LastName)
+		assert.Regexp(t, `^\p{Han}{2,3}$`, p.FullName)
+		assert.Regexp(t, `^[a-z0-9._]+$`, p.Username)
+		assert.Regexp(t, `^\d+ \w`, p.Street)
+		assert.Contains(t, deDE.Cities, p.City)
+		assert.Contains(t, enUS.States, p.State)
+		assert.Regexp(t, `^\d{6}$`, p.Zip)
+		assert.Contains(t, enUS.Countries, p.Country)
+		assert.True(t, p.Lat >= 47.3 && p.Lat <= 55.0)
+		assert.Contains(t, enUS.JobTitles, p.JobTitle)
+		ok, err := m.Valid("", p)
+		assert.True(t, ok)
+		assert.Nil(t, err)
+	}
+
+	ok, err := m.Valid("", Person{Zip: "1234", Lat: 91, Lng: "0"})
+	assert.False(t, ok)
+	assert.Equal(t, "FirstName", err.(InvalidError).Path)
+
+	var n int
+	err = m.Mock("type(latitude)", &n)
+	assert.NotNil(t, err)
+}
+
+func TestMockNet(t *testing.T) {
+	m := New(time.Now().UnixNano(), nil)
+	var err error
+	count := 10
+
+	type N struct {
+		IPv4     string           `mock:"type(ipv4) range(10.0.0.0/8)"`
+		IPv6     string           `mock:"type(ipv6)"`
+		CIDR     string           `mock:"type(cidr) range(192.168.0.0/16)"`
+		MAC      string           `mock:"type(mac)"`
+		Port     uint16           `mock:"type(port)"`
+		Host     string           `mock:"type(hostname)"`
+		URI      string           `mock:"type(uri) scheme(https|wss) format(port, path, query, fragment)"`
+		IP       net.IP           `mock:"type(ipv6) range(2001:db8::/32)"`
+		Net      *net.IPNet       `mock:"type(cidr)"`
+		Hardware net.HardwareAddr ``
+	}
+	for i := 0; i < count; i++ {
+		n := N{}
+		err = m.Mock("", &n)
+		assert.Nil(t, err)
+		assert.True(t, strings.HasPrefix(n.IPv4, "10."))
+		assert.NotNil(t, net.ParseIP(n.IPv6))
+		_, subnet, err := net.ParseCIDR(n.CIDR)
+		assert.Nil(t, err)
+		assert.True(t, strings.HasPrefix(subnet.IP.String(), "192.168."))
+		_, err = net.ParseMAC(n.MAC)
+		assert.Nil(t, err)
+		assert.True(t, n.Port > 0)
+		assert.Regexp(t, `^(https|wss)://[a-z0-9.-]+:\d+/[a-z/]+\?[a-z=&]+#[a-z]+$`, n.URI)
+		assert.Equal(t, 16, len(n.IP))
+		assert.True(t, strings.HasPrefix(n.IP.String(), "2001:db8:"))
+		assert.NotNil(t, n.Net)
+		assert.Equal(t, 6, len(n.Hardware))
+		ok, err := m.Valid("", n)
+		assert.True(t, ok)
+		assert.Nil(t, err)
+	}
+
+	ok, err := m.Valid("", N{IPv4: "11.0.0.1"})
+	assert.False(t, ok)
+	assert.Equal(t, "IPv4", err.(InvalidError).Path)
+
+	var s string
+	err = m.Mock("type(ipv4) range(2001:db8::/32)", &s)
+	assert.NotNil(t, err)
+
+	err = m.Mock("type(word) scheme(https)", &s)
+	assert.NotNil(t, err)
+}
+
+func TestMockID(t *testing.T) {
+	now := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	m := New(time.Now().UnixNano(), &Options{Now: func() time.Time { return now }})
+	var err error
+	count := 10
+
+	type N struct {
+		UUID      string   `mock:"type(uuid)"`
+		UUIDv7    string   `mock:"type(uuid) format(v7)"`
+		UUIDBytes [16]byte `mock:"type(uuid)"`
+		ULID      string   `mock:"type(ulid)"`
+		ULIDBytes []byte   `mock:"type(ulid)"`
+		ObjectID  string   `mock:"type(objectid)"`
+		Snowflake int64    `mock:"type(snowflake)"`
+	}
+	for i := 0; i < count; i++ {
+		n := N{}
+		err = m.Mock("", &n)
+		assert.Nil(t, err)
+		assert.Regexp(t, `^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`, n.UUID)
+		assert.True(t, strings.HasPrefix(n.UUIDv7, "016f6435-cc88-7"))
+		assert.Equal(t, byte(0x40), n.UUIDBytes[6]&0xf0)
+		assert.True(t, strings.HasPrefix(n.ULID, "01DXJ3BK48"))
+		assert.Equal(t, 16, len(n.ULIDBytes))
+		assert.True(t, strings.HasPrefix(n.ObjectID, "5e0d5da5"))
+		assert.Equal(t, now.UnixNano()/int64(time.Millisecond)-SnowflakeEpoch, n.Snowflake>>22)
+		ok, err := m.Valid("", n)
+		assert.True(t, ok)
+		assert.Nil(t, err)
+	}
+
+	// seed-deterministic
+	a, b := N{}, N{}
+	assert.Nil(t, New(1, &Options{Now: func() time.Time { return now }}).Mock("", &a))
+	assert.Nil(t, New(1, &Options{Now: func() time.Time { return now }}).Mock("", &b))
+	assert.Equal(t, a, b)
+
+	var short [8]byte
+	err = m.Mock("type(uuid)", &short)
+	assert.NotNil(t, err)
+}
+
+func TestMockFinance(t *testing.T) {
+	m := New(time.Now().UnixNano(), nil)
+	var err error
+	count := 20
+
+	type N struct {
+		IBAN     string  `mock:"type(iban)"`
+		DEIBAN   string  `mock:"type(iban) format(DE)"`
+		Card     string  `mock:"type(creditcard)"`
+		Amex     string  `mock:"type(creditcard) format(amex)"`
+		Currency string  `mock:"type(currency)"`
+		Price    float64 `mock:"type(amount) range(1, 100)"`
+		Yen      string  `mock:"type(amount) format(JPY) range(100, 10000)"`
+		Cents    int64   `mock:"type(amount) range([1, 2])"`
+		Dinar    string  `mock:"type(amount) format(KWD)"`
+		BIC      string  `mock:"type(bic)"`
+	}
+	for i := 0; i < count; i++ {
+		n := N{}
+		err = m.Mock("", &n)
+		assert.Nil(t, err)
+		assert.Regexp(t, `^DE\d{20}$`, n.DEIBAN)
+		assert.Regexp(t, `^3[47]\d{13}$`, n.Amex)
+		assert.Equal(t, n.Price, math.Round(n.Price*100)/100)
+		assert.Regexp(t, `^\d+$`, n.Yen)
+		assert.True(t, n.Cents >= 100 && n.Cents <= 200)
+		assert.Regexp(t, `^\d+\.\d{3}$`, n.Dinar)
+		ok, err := m.Valid("", n)
+		assert.True(t, ok)
+		assert.Nil(t, err)
+	}
+
+	// the IBAN lengths of the ISO 13616 registry
+	ibanLengths := map[string]int{"DE": 22, "FR": 27, "GB": 22, "NL": 18, "ES": 24, "IT": 27, "CH": 21, "AT": 20, "BE": 16, "PL": 28}
+	for country := range IBANFormats {
+		var s string
+		err = m.Mock("type(iban) format("+country+")", &s)
+		assert.Nil(t, err)
+		assert.Len(t, s, ibanLengths[country], country)
+	}
+
+	assert.True(t, validIBAN("GB82 WEST 1234 5698 7654 32"))
+	assert.True(t, validCreditcard("4111111111111111", "visa"))
+	ok, err := m.Valid("type(iban)", "GB82WEST12345698765433")
+	assert.False(t, ok)
+	assert.NotNil(t, err)
+	ok, _ = m.Valid("type(creditcard)", "4111111111111112")
+	assert.False(t, ok)
+	ok, _ = m.Valid("type(amount)", 1.234)
+	assert.False(t, ok)
+
+	// float32 amounts round trip
+	for i := 0; i < count; i++ {
+		var f float32
+		err = m.Mock("type(amount) range(1, 100000)", &f)
+		assert.Nil(t, err)
+		ok, err = m.Valid("type(amount)", f)
+		assert.True(t, ok, f)
+		assert.Nil(t, err)
+	}
+	ok, _ = m.Valid("type(amount)", float32(1.234))
+	assert.False(t, ok)
+
+	var s string
+	err = m.Mock("type(amount) format(XXX)", &s)
+	assert.NotNil(t, err)
+}
+
+func TestMockText(t *testing.T) {
+	m := New(time.Now().UnixNano(), nil)
+	var err error
+	count := 20
+
+	type N struct {
+		Lorem     string `mock:"type(lorem) range([5, 5])"`
+		Paragraph string `mock:"type(paragraph) range(2, 4)"`
+		Markdown  string `mock:"type(markdown) range([3, 3])"`
+		HTML      string `mock:"type(html) range(1, 3)"`
+		Chinese   string `mock:"type(paragraph) locale(zh_CN)"`
+	}
+	for i := 0; i < count; i++ {
+		n := N{}
+		err = m.Mock("", &n)
+		assert.Nil(t, err)
+		assert.True(t, strings.HasPrefix(n.Lorem, "Lorem ipsum"))
+		assert.Len(t, strings.Fields(n.Lorem), 5)
+		paragraphs := strings.Split(n.Paragraph, "\n\n")
+		assert.True(t, len(paragraphs) >= 2 && len(paragraphs) < 4)
+		for _, p := range paragraphs {
+			sentences := strings.Count(p, ".")
+			assert.True(t, sentences >= 2 && sentences <= 5, p)
+		}
+		assert.Equal(t, 3, strings.Count(n.Markdown, "\n## "))
+		assert.Regexp(t, `\[[^\]]+\]\(http://[^)]+\)`, n.Markdown)
+		assert.Regexp(t, `(?m)^- `, n.Markdown)
+		assert.True(t, strings.Count(n.HTML, "<p>") >= 1 && strings.Count(n.HTML, "<p>") < 3)
+		assert.Contains(t, n.HTML, "<ul>")
+		assert.Contains(t, n.HTML, `<a href="http://`)
+		assert.True(t, validHTML(n.HTML))
+		assert.True(t, strings.HasSuffix(n.Chinese, "。"))
+		ok, err := m.Valid("", n)
+		assert.True(t, ok)
+		assert.Nil(t, err)
+	}
+
+	ok, _ := m.Valid("type(html)", "<html><p></html>")
+	assert.False(t, ok)
+	ok, _ = m.Valid("type(markdown)", "no heading")
+	assert.False(t, ok)
+}
+
+func TestMockBlob(t *testing.T) {
+	m := New(time.Now().UnixNano(), nil)
+	var err error
+	count := 10
+
+	type N struct {
+		Raw    []byte
+		Bytes  []byte   `mock:"type(bytes) range([32, 32])"`
+		Key    [8]byte  `mock:"type(bytes)"`
+		Base64 string   `mock:"type(base64) range([12, 12])"`
+		Hex    []byte   `mock:"type(hex) range([4, 4])"`
+		PNG    []byte   `mock:"type(png) format(20x10)"`
+		JPEG   []byte   `mock:"type(jpeg)"`
+		PDF    []byte   `mock:"type(pdf) range(1, 3)"`
+		CSV    string   `mock:"type(csv) range([3, 3])"`
+		JSON   []byte   `mock:"type(json) range([4, 4])"`
+		File   []byte   `mock:"type(file) format(image/png)"`
+		Text   []byte   `mock:"type(file) format(text/html)"`
+		Files  [][]byte `mock:"range([2, 2]) elem(type(file) format(application/pdf))"`
+	}
+	for i := 0; i < count; i++ {
+		n := N{}
+		err = m.Mock("", &n)
+		assert.Nil(t, err)
+		assert.True(t, len(n.Raw) >= 1 && len(n.Raw) < 10)
+		assert.Len(t, n.Bytes, 32)
+		assert.Len(t, n.Base64, 16)
+		assert.Regexp(t, `^[0-9a-f]{8}$`, string(n.Hex))
+		assert.True(t, strings.HasPrefix(string(n.PDF), "%PDF-1.4"))
+		assert.Equal(t, 4, strings.Count(n.CSV, "\n"))
+		assert.Equal(t, 4, strings.Count(string(n.JSON), ":"))
+		assert.Equal(t, "image/png", http.DetectContentType(n.File))
+		assert.Equal(t, "image/jpeg", http.DetectContentType(n.JPEG))
+		assert.Equal(t, "application/pdf", http.DetectContentType(n.Files[1]))
+		assert.True(t, validHTML(string(n.Text)))
+		ok, err := m.Valid("", n)
+		assert.True(t, ok)
+		assert.Nil(t, err)
+	}
+
+	var b []byte
+	err = m.Mock("type(png) format(32x16)", &b)
+	assert.Nil(t, err)
+	c, err := png.DecodeConfig(bytes.NewReader(b))
+	assert.Nil(t, err)
+	assert.Equal(t, 32, c.Width)
+	assert.Equal(t, 16, c.Height)
+	ok, _ := m.Valid("type(png) format(16x16)", b)
+	assert.False(t, ok)
+	ok, _ = m.Valid("type(json)", []byte("{"))
+	assert.False(t, ok)
+
+	err = m.Mock("type(png) format(0x1)", &b)
+	assert.NotNil(t, err)
+	err = m.Mock("type(file) format(video/mp4)", &b)
+	assert.NotNil(t, err)
+	var ns []int
+	err = m.Mock("type(pdf)", &ns)
+	assert.NotNil(t, err)
+}
+
+func TestMockDev(t *testing.T) {
+	m := New(time.Now().UnixNano(), nil)
+	var err error
+	count := 20
+
+	type N struct {
+		Hex       string `mock:"type(hexcolor)"`
+		RGB       string `mock:"type(rgb)"`
+		HSL       string `mock:"type(hsl)"`
+		Version   string `mock:"type(semver)"`
+		V1        string `mock:"type(semver) range(1.2.0, 2.0.0)"`
+		Patch     string `mock:"type(semver) range([1.0.30, 1.0.32])"`
+		Mime      string `mock:"type(mimetype)"`
+		Path      string `mock:"type(filepath)"`
+		UserAgent string `mock:"type(useragent)"`
+		JWT       string `mock:"type(jwt)"`
+		Unsigned  string `mock:"type(jwt) format(none)"`
+		SHA256    string `mock:"type(sha256)"`
+	}
+	for i := 0; i < count; i++ {
+		n := N{}
+		err = m.Mock("", &n)
+		assert.Nil(t, err)
+		assert.Regexp(t, `^#[0-9a-f]{6}$`, n.Hex)
+		assert.Regexp(t, `^1\.([2-9]|1\d)\.\d+$`, n.V1)
+		assert.Contains(t, []string{"1.0.30", "1.0.31", "1.0.32"}, n.Patch)
+		assert.True(t, strings.HasSuffix(n.Unsigned, "."))
+		assert.Equal(t, 3, len(strings.Split(n.JWT, ".")))
+		ok, err := m.Valid("", n)
+		assert.True(t, ok)
+		assert.Nil(t, err)
+	}
+
+	ok, _ := m.Valid("type(semver) range(1.0.0, 2.0.0)", "2.0.0")
+	assert.False(t, ok)
+	ok, _ = m.Valid("type(semver)", "1.0.0-rc.1+build.5")
+	assert.True(t, ok)
+	ok, _ = m.Valid("type(rgb)", "rgb(256, 0, 0)")
+	assert.False(t, ok)
+	ok, _ = m.Valid("type(jwt)", "eyJhbGciOiJIUzI1NiJ9.e30.c2ln")
+	assert.False(t, ok)
+
+	var s string
+	err = m.Mock("type(word) range(1.0.0, 2.0.0)", &s)
+	assert.NotNil(t, err)
+	err = m.Mock("type(jwt) format(RS256)", &s)
+	assert.NotNil(t, err)
+}
+
+type sku string
+
+type skuType struct{}
+
+func (skuType) Kinds() []string { return []string{"string", "int64"} }
+
+func (skuType) Generate(r *rand.Rand, tag Tag, kind reflect.Kind) interface{} {
+	n := r.Int63n(9000) + 1000
+	if kind == reflect.Int64 {
+		return n
+	}
+	return fmt.Sprintf("SKU-%d", n)
+}
+
+func (skuType) Valid(tag Tag, data interface{}) string {
+	if s, ok := data.(string); ok && !regexp.MustCompile(`^SKU-\d{4}$`).MatchString(s) {
+		return "not a valid sku"
+	}
+	return ""
+}
+
+// constType is a TypeProvider generating its value
+type constType int64
+
+func (constType) Kinds() []string { return []string{"int8", "uint8", "int64"} }
+
+func (c constType) Generate(r *rand.Rand, tag Tag, kind reflect.Kind) interface{} { return int64(c) }
+
+func (constType) Valid(tag Tag, data interface{}) string { return "" }
+
+func TestRegisterType(t *testing.T) {
+	m := New(time.Now().UnixNano(), &Options{Types: map[string]TypeProvider{"sku": skuType{}}})
+	var err error
+
+	type N struct {
+		SKU   string         `mock:"type(sku)"`
+		ID    int64          `mock:"type(sku)"`
+		SKUs  []string       `mock:"elem(type(sku))"`
+		Stock map[string]int `mock:"key(type(sku))"`
+		Label string         `mock:"tmpl({{sku}}/{{word}})"`
+		Named map[sku]uint8  `mock:"range([2, 2]) key(type(sku))"`
+	}
+	n := N{}
+	err = m.Mock("", &n)
+	assert.Nil(t, err)
+	assert.Regexp(t, `^SKU-\d{4}$`, n.SKU)
+	assert.True(t, n.ID >= 1000 && n.ID < 10000)
+	for k := range n.Stock {
+		assert.Regexp(t, `^SKU-\d{4}$`, k)
+	}
+	assert.Regexp(t, `^SKU-\d{4}/[a-z]+$`, n.Label)
+	assert.Len(t, n.Named, 2)
+	ok, err := m.Valid("", n)
+	assert.True(t, ok)
+	assert.Nil(t, err)
+
+	ok, _ = m.Valid("type(sku)", "SKU-1")
+	assert.False(t, ok)
+
+	var f float64
+	err = m.Mock("type(sku)", &f)
+	assert.NotNil(t, err)
+
+	// registered types override the built-in types
+	m.RegisterType("email", skuType{})
+	var s string
+	err = m.Mock("type(email)", &s)
+	assert.Nil(t, err)
+	assert.Regexp(t, `^SKU-\d{4}$`, s)
+
+	_, err = ParseTag("string", "type(sku)")
+	assert.NotNil(t, err)
+
+	// the generated values overflowing the field type are rejected
+	m.RegisterType("big", constType(300))
+	m.RegisterType("negative", constType(-1))
+	var i8 int8
+	err = m.Mock("type(big)", &i8)
+	assert.IsType(t, ConflictError{}, err)
+	var u8 uint8
+	err = m.Mock("type(negative)", &u8)
+	assert.IsType(t, ConflictError{}, err)
+	var i64 int64
+	err = m.Mock("type(big)", &i64)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(300), i64)
+	m.RegisterType("small", constType(100))
+	err = m.Mock("type(small)", &i8)
+	assert.Nil(t, err)
+	assert.Equal(t, int8(100), i8)
+
+	// the built-in providers share the clock, sequence and GenFuncs of the Mocker
+	now := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	m = New(time.Now().UnixNano(), &Options{
+		Now:   func() time.Time { return now },
+		Types: map[string]TypeProvider{"created": builtinTypes["date"], "code": builtinTypes["word"]},
+	})
+	m.SetGenFuncs(GenFuncs{"region": func(data interface{}) interface{} { return "EU" }})
+	var created int64
+	err = m.Mock("type(created)", &created)
+	assert.Nil(t, err)
+	assert.Equal(t, now.Unix(), created)
+	for i := 1; i <= 2; i++ {
+		err = m.Mock("type(code) tmpl({{region}}-{{seq}})", &s)
+		assert.Nil(t, err)
+		assert.Equal(t, fmt.Sprintf("EU-%d", i), s)
+	}
+}
+
+type email string
+
+func (e *email) Mock(ctx *Context) error {
+	var name string
+	if err := ctx.Mock("type(username)", &name); err != nil {
+		return err
+	}
+	*e = email(name + "@example.com")
+	return nil
+}
+
+func (e email) ValidMock(ctx *Context) error {
+	if !strings.HasSuffix(string(e), "@example.com") {
+		return fmt.Errorf("not an example.com email")
+	}
+	return nil
+}
+
+func TestMockable(t *testing.T) {
+	m := New(time.Now().UnixNano(), nil)
+	m.RegisterTypeFunc(reflect.TypeOf(sql.NullString{}), func(ctx *Context) (interface{}, error) {
+		if ctx.Rand.Intn(2) == 0 {
+			return sql.NullString{}, nil
+		}
+		var s string
+		err := ctx.Mock(ctx.Tags, &s)
+		return sql.NullString{String: s, Valid: true}, err
+	})
+	var err error
+
+	type N struct {
+		Email  email
+		Emails []email `mock:"range([3, 3])"`
+		Ptr    *email
+		Name   sql.NullString `mock:"type(firstname)"`
+	}
+	for i := 0; i < 10; i++ {
+		n := N{}
+		err = m.Mock("", &n)
+		assert.Nil(t, err)
+		assert.Regexp(t, `^[a-z0-9._]+@example\.com$`, string(n.Email))
+		assert.Len(t, n.Emails, 3)
+		assert.NotNil(t, n.Ptr)
+		assert.True(t, !n.Name.Valid || n.Name.String != "")
+		ok, err := m.Valid("", n)
+		assert.True(t, ok)
+		assert.Nil(t, err)
+	}
+
+	n := N{Email: "a@b.c"}
+	ok, err := m.Valid("", n)
+	assert.False(t, ok)
+	assert.Equal(t, "Email", err.(InvalidError).Path)
+
+	m.RegisterTypeFunc(reflect.TypeOf(sql.NullInt64{}), func(ctx *Context) (interface{}, error) {
+		return int64(1), nil
+	})
+	var ni sql.NullInt64
+	err = m.Mock("", &ni)
+	assert.NotNil(t, err)
+}
+
+type level int
+
+var levels = []string{"debug", "info", "warn"}
+
+func (l *level) UnmarshalText(b []byte) error {
+	for i, s := range levels {
+		if s == string(b) {
+			*l = level(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown level %s", b)
+}
+
+func (l level) MarshalText() ([]byte, error) {
+	return []byte(levels[l]), nil
+}
+
+func TestMockUnmarshaler(t *testing.T) {
+	m := New(time.Now().UnixNano(), nil)
+	var err error
+
+	type N struct {
+		Level level         `mock:"value(debug, info, warn)"`
+		Plain level         `mock:"range(0, 3)"`
+		Big   big.Int       `mock:"pattern([1-9]\\d{30})"`
+		Time  time.Time     `mock:"type(date)"`
+		Null  sql.NullTime  `mock:"type(date)"`
+		Count sql.NullInt64 `mock:"pattern(\\d{3})"`
+	}
+	for i := 0; i < 10; i++ {
+		n := N{}
+		err = m.Mock("", &n)
+		assert.Nil(t, err)
+		assert.True(t, n.Level >= 0 && n.Level < 3)
+		assert.True(t, n.Plain >= 0 && n.Plain < 3)
+		assert.Len(t, n.Big.String(), 31)
+		assert.False(t, n.Time.IsZero())
+		assert.True(t, n.Null.Valid)
+		assert.True(t, n.Count.Valid && n.Count.Int64 < 1000)
+		ok, err := m.Valid("", n)
+		assert.True(t, ok)
+		assert.Nil(t, err)
+	}
+
+	ok, _ := m.Valid("value(debug)", level(2))
+	assert.False(t, ok)
+
+	var l level
+	err = m.Mock("value(error)", &l)
+	assert.NotNil(t, err)
+}
+
+type status int
+
+const (
+	statusActive status = iota + 1
+	statusSuspended
+	statusDeleted
+)
+
+type shade string
+
+const (
+	shadeRed   shade = "red"
+	shadeGreen shade = "green"
+)
+
+func TestRegisterEnum(t *testing.T) {
+	m := New(time.Now().UnixNano(), nil)
+	var err error
+
+	consts, err := ParseEnum(".", "status")
+	assert.Nil(t, err)
+	assert.Equal(t, []EnumConst{
+		{Name: "statusActive", Value: int64(1)},
+		{Name: "statusSuspended", Value: int64(2)},
+		{Name: "statusDeleted", Value: int64(3)},
+	}, consts)
+	err = m.RegisterEnum(reflect.TypeOf(status(0)), EnumValues(consts)...)
+	assert.Nil(t, err)
+	err = m.RegisterEnum(reflect.TypeOf(shade("")), shadeRed, shadeGreen)
+	assert.Nil(t, err)
+
+	type N struct {
+		Status   status
+		Statuses []status `mock:"range([5, 5])"`
+		Shade    *shade
+	}
+	for i := 0; i < 10; i++ {
+		n := N{}
+		err = m.Mock("", &n)
+		assert.Nil(t, err)
+		assert.Contains(t, []status{statusActive, statusSuspended, statusDeleted}, n.Status)
+		assert.Len(t, n.Statuses, 5)
+		assert.Contains(t, []shade{shadeRed, shadeGreen}, *n.Shade)
+		ok, err := m.Valid("", n)
+		assert.True(t, ok)
+		assert.Nil(t, err)
+	}
+
+	ok, err := m.Valid("", N{Status: 4})
+	assert.False(t, ok)
+	assert.Equal(t, "Status", err.(InvalidError).Path)
+
+	err = m.RegisterEnum(reflect.TypeOf(status(0)), "active")
+	assert.IsType(t, ParamError{}, err)
+	err = m.RegisterEnum(reflect.TypeOf(shade("")), 65)
+	assert.IsType(t, ParamError{}, err)
+	err = m.RegisterEnum(reflect.TypeOf(status(0)), uint8(1), 2)
+	assert.Nil(t, err)
+	type level int8
+	err = m.RegisterEnum(reflect.TypeOf(level(0)), 1, 300)
+	assert.IsType(t, ConflictError{}, err)
+	ok, _ = m.Valid("", level(44))
+	assert.True(t, ok)
+	err = m.RegisterEnum(reflect.TypeOf(level(0)), 1, -128, 127)
+	assert.Nil(t, err)
+	ok, _ = m.Valid("", level(44))
+	assert.False(t, ok)
+	_, err = ParseEnum(".", "unknown")
+	assert.NotNil(t, err)
+}
+
+func TestNameTag(t *testing.T) {
+	type Item struct {
+		SKU  string `json:"sku" mock:"pattern([A-Z]{3})"`
+		Note string `json:"note,omitempty" mock:"type(username)"`
+	}
+	type N struct {
+		Name    string `json:"name" db:"user_name"`
+		Secret  string `json:"-"`
+		Comment string `json:"comment,omitempty"`
+		Items   []Item `json:"items" mock:"range([5, 5])"`
+		Plain   int
+	}
+	m := New(time.Now().UnixNano(), &Options{
+		NameTag:   "json",
+		OmitEmpty: 0.5,
+		Fields:    map[string]string{"name": "value(alice, bob)", "items.sku": "value(ABC)"},
+	})
+	var err error
+
+	empty := 0
+	for i := 0; i < 20; i++ {
+		n := N{}
+		err = m.Mock("", &n)
+		assert.Nil(t, err)
+		assert.Contains(t, []string{"alice", "bob"}, n.Name)
+		assert.Equal(t, "", n.Secret)
+		assert.True(t, n.Plain > 0)
+		for _, item := range n.Items {
+			assert.Equal(t, "ABC", item.SKU)
+			if item.Note == "" {
+				empty++
+			}
+		}
+		ok, err := m.Valid("", n)
+		assert.True(t, ok)
+		assert.Nil(t, err)
+	}
+	assert.True(t, empty > 0 && empty < 100)
+
+	n := N{Name: "carol", Items: make([]Item, 5)}
+	for i := range n.Items {
+		n.Items[i] = Item{SKU: "ABC", Note: "X Y"}
+	}
+	_, err = m.Valid("", n)
+	assert.Equal(t, "name", err.(InvalidError).Path)
+	n.Name = "bob"
+	_, err = m.Valid("", n)
+	assert.Equal(t, "items[0].note", err.(InvalidError).Path)
+
+	m.SetNameTag("db")
+	m.SetFields(map[string]string{"user_name": "value(dave)"})
+	m.SetOmitEmpty(0)
+	n = N{}
+	err = m.Mock("", &n)
+	assert.Nil(t, err)
+	assert.Equal(t, "dave", n.Name)
+	assert.True(t, n.Secret != "")
+	assert.True(t, n.Comment != "")
+}
+
+func TestTagKeyAndFor(t *testing.T) {
+	type User struct {
+		Name  string `fake:"value(alice, bob)"`
+		Email string
+		Age   int
+		Code  string `fake:"-"`
+		Tags  []string
+	}
+	m := New(time.Now().UnixNano(), &Options{TagKey: "fake"})
+	m.For(&User{}).
+		Field("Email").Type("email").Pattern(`[a-z]{12}@example\.com`).Unique().
+		Field("Age").Range(18, 65).
+		Field("Tags").Tags("range([2, 2])").Elem("value(a, b)")
+	var err error
+
+	seen := map[string]bool{}
+	for i := 0; i < 20; i++ {
+		u := User{}
+		err = m.Mock("", &u)
+		assert.Nil(t, err)
+		assert.Contains(t, []string{"alice", "bob"}, u.Name)
+		assert.Regexp(t, `^[a-z]{12}@example\.com$`, u.Email)
+		assert.False(t, seen[u.Email])
+		seen[u.Email] = true
+		assert.True(t, u.Age >= 18 && u.Age < 65)
+		assert.Equal(t, "", u.Code)
+		assert.Len(t, u.Tags, 2)
+		ok, err := m.Valid("", u)
+		assert.True(t, ok)
+		assert.Nil(t, err)
+	}
+
+	ok, _ := m.Valid("", User{Name: "alice", Email: "a@example.com"})
+	assert.False(t, ok)
+
+	// unique values run out
+	type Flag struct{ On bool }
+	m.For(Flag{}).Field("On").Unique()
+	var flags [3]Flag
+	err = m.Mock("", &flags)
+	assert.NotNil(t, err)
+
+	// the error of an earlier field does not stop the maps and unique fields
+	type Order struct {
+		Bad   int `fake:"type(unknown)"`
+		Email string
+		Items map[string]int `fake:"range([3, 3])"`
+	}
+	m.For(&Order{}).Field("Email").Type("word").Unique()
+	o := Order{}
+	err = m.Mock("", &o)
+	assert.IsType(t, ParamError{}, err)
+	assert.NotEqual(t, "", o.Email)
+	assert.Len(t, o.Items, 3)
+
+	m.For(&User{}).Field("Missing").Type("word")
+	err = m.Mock("", &User{})
+	assert.NotNil(t, err)
+	assert.NotNil(t, m.For(User{}).Err())
+
+	// the configurations of non-struct types are invalid
+	pp := new(*int)
+	for _, data := range []interface{}{new(int), pp, nil} {
+		var s *StructConfig
+		assert.NotPanics(t, func() { s = m.For(data).Field("Name").Type("word").Field("Age").s })
+		assert.IsType(t, ParamError{}, s.Err())
+	}
+}
+
+func TestValidatorTags(t *testing.T) {
+	type User struct {
+		Name   string         `validate:"required,min=3,max=20"`
+		Email  string         `validate:"required,email"`
+		Role   string         `validate:"oneof=admin user guest"`
+		Age    int            `validate:"gt=0,lte=130"`
+		Score  float64        `validate:"gte=0,lt=1"`
+		ID     string         `validate:"uuid4"`
+		Tags   []string       `validate:"min=1,max=3,dive,len=4"`
+		Attrs  map[string]int `validate:"len=2,dive,keys,min=2,endkeys,min=5,max=9"`
+		Nick   string         `mock:"value(neo)" validate:"email"`
+		Short  string         `validate:"max=12,email"`
+		Ignore string         `validate:"alphanum"`
+	}
+	tags, err := ValidatorTags("required,min=3,max=20", reflect.TypeOf(""))
+	assert.Nil(t, err)
+	assert.Equal(t, "range(3, 20])", tags)
+	tags, err = ValidatorTags("gt=0,lte=130", reflect.TypeOf(0))
+	assert.Nil(t, err)
+	assert.Equal(t, "range(]0, 130])", tags)
+	tags, err = ValidatorTags("max=3,dive,oneof=a b", reflect.TypeOf([]string{}))
+	assert.Nil(t, err)
+	assert.Equal(t, "range(1, 3]) elem(value(a, b))", tags)
+	tags, err = ValidatorTags("len=2,dive, keys,min=2, endkeys", reflect.TypeOf(map[string]int{}))
+	assert.Nil(t, err)
+	assert.Equal(t, "range(2, 2]) key(range(2, 12))", tags)
+	_, err = ValidatorTags("dive,min=1", reflect.TypeOf(""))
+	assert.NotNil(t, err)
+	_, err = ValidatorTags("min=x", reflect.TypeOf(""))
+	assert.NotNil(t, err)
+
+	m := New(time.Now().UnixNano(), &Options{ValidateTag: "validate"})
+	uuidRe := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	for i := 0; i < 50; i++ {
+		u := User{}
+		err = m.Mock("", &u)
+		assert.Nil(t, err)
+		assert.True(t, len(u.Name) >= 3 && len(u.Name) <= 20, u.Name)
+		assert.Regexp(t, `^[^@\s]+@[^@\s]+\.[^@\s]+$`, u.Email)
+		assert.Contains(t, []string{"admin", "user", "guest"}, u.Role)
+		assert.True(t, u.Age > 0 && u.Age <= 130, u.Age)
+		assert.True(t, u.Score >= 0 && u.Score < 1, u.Score)
+		assert.Regexp(t, uuidRe, u.ID)
+		assert.True(t, len(u.Tags) >= 1 && len(u.Tags) <= 3)
+		for _, tag := range u.Tags {
+			assert.Len(t, tag, 4)
+		}
+		assert.Len(t, u.Attrs, 2)
+		for k, v := range u.Attrs {
+			assert.True(t, len(k) >= 2, k)
+			assert.True(t, v >= 5 && v <= 9, v)
+		}
+		assert.Equal(t, "neo", u.Nick)
+		assert.True(t, len(u.Short) <= 12, u.Short)
+		ok, err := m.Valid("", u)
+		assert.True(t, ok)
+		assert.Nil(t, err)
+	}
+
+	u := User{}
+	assert.Nil(t, m.Mock("", &u))
+	invalid := []struct {
+		path string
+		set  func(u *User)
+	}{
+		{"Name", func(u *User) { u.Name = "ab" }},
+		{"Email", func(u *User) { u.Email = "ab" }},
+		{"Role", func(u *User) { u.Role = "root" }},
+		{"Age", func(u *User) { u.Age = 0 }},
+		{"Score", func(u *User) { u.Score = 1 }},
+		{"Tags", func(u *User) { u.Tags = []string{"abcd", "abcd", "abcd", "abcd"} }},
+		{"Tags[0]", func(u *User) { u.Tags = []string{"abc"} }},
+		{"Attrs[ab]", func(u *User) { u.Attrs = map[string]int{"ab": 1, "cd": 5} }},
+		{"Short", func(u *User) { u.Short = "abcdef@example.com" }},
+	}
+	for _, c := range invalid {
+		v := u
+		c.set(&v)
+		_, err = m.Valid("", v)
+		if assert.IsType(t, InvalidError{}, err, c.path) {
+			assert.Equal(t, c.path, err.(InvalidError).Path)
+		}
+	}
+
+	m.SetValidateTag("")
+	u.Name = "ab"
+	_, err = m.Valid("", u)
+	assert.Nil(t, err)
+
+	// no email fits in 3 runes
+	var email string
+	err = m.Mock("range(1, 3]) type(email)", &email)
+	assert.IsType(t, ConflictError{}, err)
+}
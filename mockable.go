@@ -0,0 +1,147 @@
+package mock
+
+import (
+	"math/rand"
+	"reflect"
+	"time"
+)
+
+// Mockable is implemented by the types mocking themselves, Mock is called
+// on the pointer of the value before the reflection of its kind
+type Mockable interface {
+	Mock(ctx *Context) error
+}
+
+// Validatable is implemented by the types validating themselves, ValidMock
+// is called before the reflection of its kind
+type Validatable interface {
+	ValidMock(ctx *Context) error
+}
+
+// TypeFunc return a value of the type registered by RegisterTypeFunc
+type TypeFunc func(ctx *Context) (interface{}, error)
+
+var (
+	mockableType    = reflect.TypeOf((*Mockable)(nil)).Elem()
+	validatableType = reflect.TypeOf((*Validatable)(nil)).Elem()
+)
+
+// Context is the context of Mockable, Validatable and TypeFunc
+type Context struct {
+	Tag  Tag    // parsed tag of the value
+	Tags string // tags of the value
+	Path string // path of the value, e.g. A.B[0]
+	Rand *rand.Rand
+
+	m *mocker
+}
+
+// Now return the clock of the Mocker
+func (c *Context) Now() time.Time {
+	return c.m.gen.now()
+}
+
+// Mock mock data with tags by the Mocker, data must be a pointer
+func (c *Context) Mock(tags string, data interface{}) error {
+	v := reflect.ValueOf(data)
+	if v.Kind() != reflect.Ptr {
+		return NewParamError("data", "pointer", v.Kind())
+	}
+	err := c.m.err
+	c.m.err = nil
+	c.m.mock(c.Path, tags, v.Elem())
+	err, c.m.err = c.m.err, err
+	return err
+}
+
+// Valid valid data with tags by the Mocker
+func (c *Context) Valid(tags string, data interface{}) error {
+	err := c.m.err
+	c.m.err = nil
+	c.m.valid(c.Path, tags, reflect.ValueOf(data))
+	err, c.m.err = c.m.err, err
+	return err
+}
+
+func (m *mocker) context(path, tags string, t Tag) *Context {
+	return &Context{Tag: t, Tags: tags, Path: path, Rand: m.gen.rand, m: m}
+}
+
+// RegisterTypeFunc register the TypeFunc mocking the values of typ,
+// for the types which can not implement Mockable
+func (m *mocker) RegisterTypeFunc(typ reflect.Type, fn TypeFunc) {
+	if m.typeFuncs == nil {
+		m.typeFuncs = map[reflect.Type]TypeFunc{}
+	}
+	m.typeFuncs[typ] = fn
+}
+
+// isCustom report whether v is mocked by a registered TypeFunc or Mockable,
+// the tags of the custom types are parsed as a string field
+func (m *mocker) isCustom(v reflect.Value) bool {
+	_, ok := m.typeFuncs[v.Type()]
+	return ok || v.CanAddr() && v.Addr().Type().Implements(mockableType)
+}
+
+// mockCustom mock v by the registered TypeFunc or Mockable
+func (m *mocker) mockCustom(path, tags string, v reflect.Value) {
+	t := m.parseTag("string", tags)
+	if fn, ok := m.genFuncs[t.GenFunc]; ok {
+		v.Set(reflect.ValueOf(fn(m.current)))
+		return
+	}
+	fn, ok := m.typeFuncs[v.Type()]
+	if !ok {
+		if err := v.Addr().Interface().(Mockable).Mock(m.context(path, tags, t)); err != nil {
+			m.err = err
+		}
+		return
+	}
+	val, err := fn(m.context(path, tags, t))
+	if err != nil {
+		m.err = err
+		return
+	}
+	if val == nil {
+		return
+	}
+	rv := reflect.ValueOf(val)
+	if !rv.Type().AssignableTo(v.Type()) {
+		m.err = NewConflictError("fieldType", v.Type().String(), "TypeFunc", rv.Type().String(), "TypeFunc need return the registered type")
+		return
+	}
+	v.Set(rv)
+}
+
+// validatable return the Validatable of v, or nil if v is not a Validatable
+func validatable(v reflect.Value) Validatable {
+	switch {
+	case v.Type().Implements(validatableType):
+		return v.Interface().(Validatable)
+	case reflect.PtrTo(v.Type()).Implements(validatableType):
+		p := reflect.New(v.Type())
+		p.Elem().Set(v)
+		return p.Interface().(Validatable)
+	}
+	return nil
+}
+
+// validCustom valid v by Validatable, the values of registered TypeFunc without
+// Validatable are only validated by valid tag func
+func (m *mocker) validCustom(path, tags string, v reflect.Value) bool {
+	val := validatable(v)
+	if _, ok := m.typeFuncs[v.Type()]; !ok && val == nil {
+		return false
+	}
+	t := m.parseTag("string", tags)
+	if m.err != nil || !m.validFunc(path, t, v) || val == nil {
+		return true
+	}
+	if err := val.ValidMock(m.context(path, tags, t)); err != nil {
+		if _, ok := err.(InvalidError); !ok {
+			err = NewInvalidError(path, v.Interface(), err.Error())
+		}
+		m.err = err
+	}
+	return true
+}
@@ -0,0 +1,255 @@
+package mock
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	ipType    = reflect.TypeOf(net.IP{})
+	ipNetType = reflect.TypeOf(net.IPNet{})
+	macType   = reflect.TypeOf(net.HardwareAddr{})
+)
+
+// netTypes maps the field types of net package to the typ used by ParseTag
+var netTypes = map[reflect.Type]string{
+	ipType:    "net.IP",
+	ipNetType: "net.IPNet",
+	macType:   "net.HardwareAddr",
+}
+
+// defaultNetTypes is the type of the net field types without type tag func
+var defaultNetTypes = map[string]string{
+	"net.IP":           "ipv4",
+	"net.IPNet":        "cidr",
+	"net.HardwareAddr": "mac",
+}
+
+// defaultIPv6Subnet is the global unicast address space
+var defaultIPv6Subnet = &net.IPNet{IP: net.IP{0x20, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, Mask: net.CIDRMask(3, 128)}
+
+// URIParts is the avaliable parts in format tag func of uri
+var URIParts = []string{"userinfo", "port", "path", "query", "fragment"}
+
+// parseSubnet parse the range of ip and cidr, e.g. range(10.0.0.0/8)
+func parseSubnet(t *Tag, param string) error {
+	_, subnet, err := net.ParseCIDR(strings.TrimSpace(param))
+	if err != nil {
+		return NewParamError("range", "CIDR subnet", param)
+	}
+	t.Subnet = subnet
+	return nil
+}
+
+// checkNet check the conflicts of net tag funcs after all tag funcs are parsed
+func checkNet(t *Tag) error {
+	if t.Subnet != nil {
+		v4 := t.Subnet.IP.To4() != nil
+		switch {
+		case t.Type != "ipv4" && t.Type != "ipv6" && t.Type != "cidr":
+			return NewConflictError("type", t.Type, "range", t.Subnet, "subnet need type ipv4, ipv6 or cidr")
+		case t.Type == "ipv4" && !v4, t.Type == "ipv6" && v4:
+			return NewConflictError("type", t.Type, "range", t.Subnet, "subnet need the same ip version")
+		}
+	}
+	if len(t.Schemes) > 0 && t.Type != "uri" && t.Type != "url" {
+		return NewConflictError("type", t.Type, "scheme", strings.Join(t.Schemes, "|"), "scheme need type uri or url")
+	}
+	if t.Type == "uri" && t.Format != "" {
+		for _, p := range strings.Split(t.Format, ",") {
+			if !contains(URIParts, strings.TrimSpace(p)) {
+				return NewParamError("format", strings.Join(URIParts, "/"), p)
+			}
+		}
+	}
+	return nil
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// ip return a random ip in subnet
+func (g generator) ip(subnet *net.IPNet) net.IP {
+	ip := make(net.IP, len(subnet.IP))
+	for i := range ip {
+		ip[i] = subnet.IP[i]&subnet.Mask[i] | byte(g.rand.Intn(256))&^subnet.Mask[i]
+	}
+	return ip
+}
+
+func (g generator) ipv4Subnet(tag Tag) *net.IPNet {
+	if tag.Subnet != nil {
+		return tag.Subnet
+	}
+	return &net.IPNet{IP: net.IPv4zero.To4(), Mask: net.CIDRMask(0, 32)}
+}
+
+func (g generator) ipv6Subnet(tag Tag) *net.IPNet {
+	if tag.Subnet != nil {
+		return tag.Subnet
+	}
+	return defaultIPv6Subnet
+}
+
+// cidr return a random network in tag.Subnet, default a ipv4 network with prefix length in [8, 30]
+func (g generator) cidr(tag Tag) *net.IPNet {
+	subnet := tag.Subnet
+	if subnet == nil {
+		subnet = &net.IPNet{IP: g.ip(g.ipv4Subnet(tag)), Mask: net.CIDRMask(8, 32)}
+	}
+	ones, bits := subnet.Mask.Size()
+	max := bits
+	if tag.Subnet == nil {
+		max = 30
+	}
+	mask := net.CIDRMask(ones+int(g.int63n(int64(max-ones+1))), bits)
+	ip := g.ip(subnet).Mask(mask)
+	return &net.IPNet{IP: ip, Mask: mask}
+}
+
+func (g generator) mac() net.HardwareAddr {
+	mac := make(net.HardwareAddr, 6)
+	binary.BigEndian.PutUint16(mac, uint16(g.rand.Intn(1<<16)))
+	binary.BigEndian.PutUint32(mac[2:], g.rand.Uint32())
+	// locally administered unicast address
+	mac[0] = mac[0]&^1 | 2
+	return mac
+}
+
+func (g generator) port() int64 {
+	return g.int63n(65535) + 1
+}
+
+func (g generator) hostname() string {
+	host := g.word(3, 10)
+	if g.int63n(2) == 0 {
+		host += "-" + strconv.FormatInt(g.int63n(100), 10)
+	}
+	return host + "." + g.word(3, 10) + "." + g.pick([]string{"com", "net", "org", "io", "internal", "local"})
+}
+
+// uri return a random uri with the schemes and the parts in format of tag
+func (g generator) uri(tag Tag) string {
+	schemes := tag.Schemes
+	if len(schemes) == 0 {
+		schemes = []string{"http", "https"}
+	}
+	parts := []string{"path"}
+	if tag.Format != "" {
+		parts = strings.Split(strings.Replace(tag.Format, " ", "", -1), ",")
+	}
+
+	u := url.URL{Scheme: g.pick(schemes), Host: g.hostname()}
+	if contains(parts, "userinfo") {
+		u.User = url.UserPassword(g.word(3, 8), g.word(6, 12))
+	}
+	if contains(parts, "port") {
+		u.Host += ":" + strconv.FormatInt(g.port(), 10)
+	}
+	if contains(parts, "path") {
+		segments := make([]string, g.int63n(3)+1)
+		for i := range segments {
+			segments[i] = g.word(1, 10)
+		}
+		u.Path = "/" + strings.Join(segments, "/")
+	}
+	if contains(parts, "query") {
+		q := url.Values{}
+		for i := g.int63n(3) + 1; i > 0; i-- {
+			q.Set(g.word(1, 8), g.word(1, 8))
+		}
+		u.RawQuery = q.Encode()
+	}
+	if contains(parts, "fragment") {
+		u.Fragment = g.word(1, 10)
+	}
+	return u.String()
+}
+
+// mockNet mock the field types of net package
+func (m *mocker) mockNet(t Tag, v reflect.Value) {
+	if t.Type == "" {
+		t.Type = defaultNetTypes[netTypes[v.Type()]]
+	}
+	switch t.Type {
+	case "ipv4":
+		v.Set(reflect.ValueOf(m.gen.ip(m.gen.ipv4Subnet(t))))
+	case "ipv6":
+		v.Set(reflect.ValueOf(m.gen.ip(m.gen.ipv6Subnet(t))))
+	case "cidr":
+		v.Set(reflect.ValueOf(*m.gen.cidr(t)))
+	case "mac":
+		v.Set(reflect.ValueOf(m.gen.mac()))
+	}
+}
+
+// netString return the string form of the field types of net package
+func netString(v reflect.Value) (string, bool) {
+	switch v.Type() {
+	case ipType:
+		return v.Interface().(net.IP).String(), true
+	case ipNetType:
+		n := v.Interface().(net.IPNet)
+		return n.String(), true
+	case macType:
+		return v.Interface().(net.HardwareAddr).String(), true
+	}
+	return "", false
+}
+
+var hostnameRe = regexp.MustCompile(`^(?i)[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?(\.[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?)*$`)
+
+// validNet return why s is not a valid value of the net types, or "" if it is valid
+func validNet(t Tag, s string) string {
+	ok := true
+	switch t.Type {
+	case "ipv4", "ipv6":
+		ip := net.ParseIP(s)
+		ok = ip != nil && (ip.To4() != nil && strings.Contains(s, ".")) == (t.Type == "ipv4")
+		if ok && t.Subnet != nil && !t.Subnet.Contains(ip) {
+			return fmt.Sprintf("not in subnet %s", t.Subnet)
+		}
+	case "cidr":
+		ip, n, err := net.ParseCIDR(s)
+		ok = err == nil
+		if ok && t.Subnet != nil {
+			ones, _ := n.Mask.Size()
+			subnetOnes, _ := t.Subnet.Mask.Size()
+			if !t.Subnet.Contains(ip) || ones < subnetOnes {
+				return fmt.Sprintf("not in subnet %s", t.Subnet)
+			}
+		}
+	case "mac":
+		_, err := net.ParseMAC(s)
+		ok = err == nil
+	case "port":
+		n, err := strconv.ParseInt(s, 10, 64)
+		ok = err == nil && n > 0 && n <= 65535
+	case "hostname":
+		ok = len(s) <= 253 && hostnameRe.MatchString(s)
+	case "uri", "url":
+		u, err := url.Parse(s)
+		ok = err == nil && u.Scheme != "" && u.Host != ""
+		if ok && len(t.Schemes) > 0 && !contains(t.Schemes, u.Scheme) {
+			return fmt.Sprintf("scheme not in %s", strings.Join(t.Schemes, "|"))
+		}
+	default:
+		return ""
+	}
+	if !ok {
+		return "not a valid " + t.Type
+	}
+	return ""
+}
@@ -0,0 +1,284 @@
+// Package openapi generates and validates the request and response bodies of OpenAPI 3 operations,
+// the schemas are generated by the schema package
+package openapi
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/CJH9004/mock"
+	"github.com/CJH9004/mock/schema"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Methods is the operation methods of path items
+var Methods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// Spec is a loaded OpenAPI document
+type Spec struct {
+	doc    map[string]interface{}
+	schema *schema.Schema
+	m      mock.Mocker
+}
+
+// Load load the OpenAPI YAML or JSON file, m generates the bodies, a Mocker with random seed is used if m is nil
+func Load(path string, m mock.Mocker) (*Spec, error) {
+	file, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return parse(data, file, m)
+}
+
+// Parse parse the OpenAPI YAML or JSON document
+func Parse(data []byte, m mock.Mocker) (*Spec, error) {
+	return parse(data, "", m)
+}
+
+func parse(data []byte, file string, m mock.Mocker) (*Spec, error) {
+	var doc interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	root, ok := jsonValue(doc).(map[string]interface{})
+	if !ok {
+		return nil, mock.NewParamError("document", "OpenAPI object", doc)
+	}
+	if m == nil {
+		m = mock.New(time.Now().UnixNano(), nil)
+	}
+	return &Spec{doc: root, schema: schema.Document(root, file, m), m: m}, nil
+}
+
+// jsonValue convert the YAML value to the JSON value decoded by encoding/json
+func jsonValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		obj := make(map[string]interface{}, len(v))
+		for k, val := range v {
+			obj[fmt.Sprint(k)] = jsonValue(val)
+		}
+		return obj
+	case []interface{}:
+		for i, val := range v {
+			v[i] = jsonValue(val)
+		}
+		return v
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	case time.Time:
+		return v.Format(time.RFC3339)
+	}
+	return v
+}
+
+// Request return a random request body of the operation, the readOnly properties are omitted
+func (s *Spec) Request(operationID string) (interface{}, error) {
+	media, pointer, err := s.requestMedia(operationID)
+	if err != nil {
+		return nil, err
+	}
+	return s.generate(media, pointer, schema.AccessWrite)
+}
+
+// Response return a random body of the response of the operation with status code, e.g. 200,
+// the status falls back to the range like 2XX and default, the writeOnly properties are omitted
+func (s *Spec) Response(operationID, status string) (interface{}, error) {
+	media, pointer, err := s.responseMedia(operationID, status)
+	if err != nil {
+		return nil, err
+	}
+	return s.generate(media, pointer, schema.AccessRead)
+}
+
+// ValidateRequest valid the request body of the operation, readOnly properties are rejected
+func (s *Spec) ValidateRequest(operationID string, body interface{}) error {
+	media, pointer, err := s.requestMedia(operationID)
+	if err != nil {
+		return err
+	}
+	return s.validate(media, pointer, schema.AccessWrite, body)
+}
+
+// ValidateResponse valid the response body of the operation with status code, writeOnly properties are rejected
+func (s *Spec) ValidateResponse(operationID, status string, body interface{}) error {
+	media, pointer, err := s.responseMedia(operationID, status)
+	if err != nil {
+		return err
+	}
+	return s.validate(media, pointer, schema.AccessRead, body)
+}
+
+// generate return the example of media, a random value of examples, or a value generated by the schema
+func (s *Spec) generate(media map[string]interface{}, pointer string, access schema.Access) (interface{}, error) {
+	if v, ok := media["example"]; ok {
+		return v, nil
+	}
+	if examples, ok := media["examples"].(map[string]interface{}); ok && len(examples) > 0 {
+		names := sortedKeys(examples)
+		var i int
+		if err := s.m.Mock(fmt.Sprintf("range(0, %d)", len(names)), &i); err != nil {
+			return nil, err
+		}
+		example, _, err := s.resolve(examples[names[i]], "")
+		if err != nil {
+			return nil, err
+		}
+		if v, ok := example["value"]; ok {
+			return v, nil
+		}
+	}
+	sch, err := s.mediaSchema(media, pointer, access)
+	if err != nil {
+		return nil, err
+	}
+	return sch.Generate()
+}
+
+func (s *Spec) validate(media map[string]interface{}, pointer string, access schema.Access, body interface{}) error {
+	sch, err := s.mediaSchema(media, pointer, access)
+	if err != nil {
+		return err
+	}
+	return sch.Validate(body)
+}
+
+func (s *Spec) mediaSchema(media map[string]interface{}, pointer string, access schema.Access) (*schema.Schema, error) {
+	if _, ok := media["schema"]; !ok {
+		return nil, mock.NewParamError("schema", "media type with schema", pointer)
+	}
+	sch, err := s.schema.Ref("#" + pointer + "/schema")
+	if err != nil {
+		return nil, err
+	}
+	sch.SetAccess(access)
+	sch.SetExamples(true)
+	return sch, nil
+}
+
+// operation return the operation object of operationID and its JSON pointer
+func (s *Spec) operation(operationID string) (map[string]interface{}, string, error) {
+	paths, _ := s.doc["paths"].(map[string]interface{})
+	for _, path := range sortedKeys(paths) {
+		item, pointer, err := s.resolve(paths[path], "/paths/"+escape(path))
+		if err != nil {
+			return nil, "", err
+		}
+		for _, method := range Methods {
+			op, ok := item[method].(map[string]interface{})
+			if ok && op["operationId"] == operationID {
+				return op, pointer + "/" + method, nil
+			}
+		}
+	}
+	return nil, "", mock.NewParamError("operationID", "operationId in paths", operationID)
+}
+
+func (s *Spec) requestMedia(operationID string) (map[string]interface{}, string, error) {
+	op, pointer, err := s.operation(operationID)
+	if err != nil {
+		return nil, "", err
+	}
+	body, ok := op["requestBody"]
+	if !ok {
+		return nil, "", mock.NewParamError("operationID", "operation with requestBody", operationID)
+	}
+	req, pointer, err := s.resolve(body, pointer+"/requestBody")
+	if err != nil {
+		return nil, "", err
+	}
+	return media(req, pointer)
+}
+
+func (s *Spec) responseMedia(operationID, status string) (map[string]interface{}, string, error) {
+	op, pointer, err := s.operation(operationID)
+	if err != nil {
+		return nil, "", err
+	}
+	responses, _ := op["responses"].(map[string]interface{})
+	codes := []string{status, "default"}
+	if status != "" {
+		codes = []string{status, status[:1] + "XX", "default"}
+	}
+	for _, code := range codes {
+		if r, ok := responses[code]; ok {
+			resp, pointer, err := s.resolve(r, pointer+"/responses/"+escape(code))
+			if err != nil {
+				return nil, "", err
+			}
+			return media(resp, pointer)
+		}
+	}
+	return nil, "", mock.NewParamError("status", "response of "+operationID, status)
+}
+
+// media return the JSON media type object of the request body or response obj, or the first one
+func media(obj map[string]interface{}, pointer string) (map[string]interface{}, string, error) {
+	content, _ := obj["content"].(map[string]interface{})
+	types := sortedKeys(content)
+	if len(types) == 0 {
+		return nil, "", mock.NewParamError("content", "media types", pointer)
+	}
+	name := types[0]
+	for _, t := range types {
+		if strings.HasSuffix(t, "+json") {
+			name = t
+			break
+		}
+	}
+	if _, ok := content["application/json"]; ok {
+		name = "application/json"
+	}
+	m, _ := content[name].(map[string]interface{})
+	return m, pointer + "/content/" + escape(name), nil
+}
+
+// resolve return the object of node following the $ref and its JSON pointer
+func (s *Spec) resolve(node interface{}, pointer string) (map[string]interface{}, string, error) {
+	for i := 0; i < schema.MaxDepth; i++ {
+		obj, ok := node.(map[string]interface{})
+		if !ok {
+			return nil, "", mock.NewParamError("object", "OpenAPI object", pointer)
+		}
+		ref, ok := obj["$ref"].(string)
+		if !ok {
+			return obj, pointer, nil
+		}
+		if !strings.HasPrefix(ref, "#/") {
+			return nil, "", mock.NewParamError("$ref", "JSON pointer in the document", ref)
+		}
+		pointer, node = ref[1:], interface{}(s.doc)
+		for _, token := range strings.Split(ref[2:], "/") {
+			token = strings.Replace(strings.Replace(token, "~1", "/", -1), "~0", "~", -1)
+			m, _ := node.(map[string]interface{})
+			node = m[token]
+		}
+	}
+	return nil, "", mock.NewParamError("$ref", "non-circular $ref", pointer)
+}
+
+// escape escape the token of JSON pointer
+func escape(token string) string {
+	return strings.Replace(strings.Replace(token, "~", "~0", -1), "/", "~1", -1)
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
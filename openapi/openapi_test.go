@@ -0,0 +1,198 @@
+package openapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/CJH9004/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+const petstore = `
+openapi: 3.0.3
+info:
+  title: Petstore
+  version: 1.0.0
+paths:
+  /pets:
+    post:
+      operationId: createPet
+      requestBody:
+        $ref: '#/components/requestBodies/Pet'
+      responses:
+        201:
+          description: created
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+        4XX:
+          $ref: '#/components/responses/Error'
+  /pets/{id}:
+    get:
+      operationId: getPet
+      responses:
+        200:
+          description: pet
+          content:
+            application/json:
+              example: {id: 1, name: tom, petType: cat, lives: 9}
+        default:
+          $ref: '#/components/responses/Error'
+components:
+  requestBodies:
+    Pet:
+      content:
+        application/json:
+          schema:
+            $ref: '#/components/schemas/Pet'
+  responses:
+    Error:
+      description: error
+      content:
+        application/problem+json:
+          examples:
+            notFound:
+              value: {code: 404, message: not found}
+            invalid:
+              $ref: '#/components/examples/Invalid'
+  examples:
+    Invalid:
+      value: {code: 400, message: invalid}
+  schemas:
+    Pet:
+      oneOf:
+        - $ref: '#/components/schemas/Cat'
+        - $ref: '#/components/schemas/Dog'
+      discriminator:
+        propertyName: petType
+        mapping:
+          dog: Dog
+    Base:
+      type: object
+      required: [id, name, petType, secret]
+      properties:
+        id:
+          type: integer
+          minimum: 1
+          readOnly: true
+        name:
+          type: string
+          minLength: 2
+          maxLength: 10
+        petType:
+          type: string
+        owner:
+          type: string
+          nullable: true
+          maxLength: 5
+        secret:
+          type: string
+          writeOnly: true
+    Cat:
+      allOf:
+        - $ref: '#/components/schemas/Base'
+        - type: object
+          required: [lives]
+          properties:
+            lives:
+              type: integer
+              minimum: 1
+              maximum: 9
+    Dog:
+      allOf:
+        - $ref: '#/components/schemas/Base'
+        - type: object
+          required: [bark]
+          properties:
+            bark:
+              type: string
+              example: woof
+`
+
+func TestSpec(t *testing.T) {
+	s, err := Parse([]byte(petstore), mock.New(time.Now().UnixNano(), nil))
+	assert.Nil(t, err)
+
+	petTypes := map[interface{}]bool{}
+	owners := map[bool]bool{}
+	// the optional owner is null or omitted randomly, continue until both kinds are seen
+	for i := 0; i < 50 || (len(petTypes) < 2 || len(owners) < 2) && i < 1000; i++ {
+		req, err := s.Request("createPet")
+		assert.Nil(t, err)
+		pet := req.(map[string]interface{})
+		assert.NotContains(t, pet, "id")
+		assert.Contains(t, pet, "secret")
+		assert.Nil(t, s.ValidateRequest("createPet", req))
+		petTypes[pet["petType"]] = true
+		if pet["petType"] == "dog" {
+			assert.Equal(t, "woof", pet["bark"])
+		} else {
+			assert.Equal(t, "Cat", pet["petType"])
+			lives := pet["lives"].(int64)
+			assert.True(t, lives >= 1 && lives <= 9)
+		}
+		if owner, ok := pet["owner"]; ok {
+			owners[owner == nil] = true
+		}
+
+		resp, err := s.Response("createPet", "201")
+		assert.Nil(t, err)
+		pet = resp.(map[string]interface{})
+		assert.Contains(t, pet, "id")
+		assert.NotContains(t, pet, "secret")
+		assert.Nil(t, s.ValidateResponse("createPet", "201", resp))
+	}
+	assert.Len(t, petTypes, 2)
+	assert.Len(t, owners, 2)
+
+	resp, err := s.Response("getPet", "200")
+	assert.Nil(t, err)
+	assert.Equal(t, "tom", resp.(map[string]interface{})["name"])
+	codes := map[interface{}]bool{}
+	for i := 0; i < 20; i++ {
+		resp, err = s.Response("createPet", "404")
+		assert.Nil(t, err)
+		codes[resp.(map[string]interface{})["code"]] = true
+	}
+	assert.Equal(t, map[interface{}]bool{404.0: true, 400.0: true}, codes)
+	_, err = s.Response("getPet", "500")
+	assert.Nil(t, err)
+
+	pet := map[string]interface{}{"name": "rex", "petType": "dog", "bark": "woof", "secret": "x"}
+	assert.Nil(t, s.ValidateRequest("createPet", pet))
+	invalid := []struct {
+		path string
+		key  string
+		val  interface{}
+	}{
+		{"id", "id", 1},
+		{"petType", "petType", "bird"},
+		{"name", "name", "r"},
+		{"bark", "bark", nil},
+		{"owner", "owner", "too long"},
+	}
+	for _, c := range invalid {
+		v := map[string]interface{}{}
+		for k, val := range pet {
+			v[k] = val
+		}
+		if c.val == nil {
+			delete(v, c.key)
+		} else {
+			v[c.key] = c.val
+		}
+		err = s.ValidateRequest("createPet", v)
+		if assert.IsType(t, mock.InvalidError{}, err, c.path) {
+			assert.Equal(t, c.path, err.(mock.InvalidError).Path)
+		}
+	}
+	pet["owner"] = nil
+	assert.Nil(t, s.ValidateRequest("createPet", pet))
+	assert.NotNil(t, s.ValidateResponse("createPet", "201", pet))
+
+	_, err = s.Request("unknown")
+	assert.IsType(t, mock.ParamError{}, err)
+	_, err = s.Request("getPet")
+	assert.IsType(t, mock.ParamError{}, err)
+}
@@ -2,9 +2,12 @@ package mock
 
 import (
 	"fmt"
-	"regexp"
+	"math"
+	"net"
+	"regexp/syntax"
 	"strconv"
 	"strings"
+	"unicode"
 )
 
 // todo: error handle; add tag funcs: key(tag) elem(tag) range(1,2) format(Mon Jan 2 15:04:05 -0700 MST 2006) ;
@@ -53,30 +56,158 @@ func NewConflictError(name1 string, param1 interface{}, name2 string, param2 int
 	}
 }
 
-// TypeList is the avalid type
-var TypeList = []string{"eamil", "date", "phone", "url", "ipv4", "domain", "word", "sentence"}
+// TypeList is the built-in types of type tag func, more types can be registered by Mocker.RegisterType
+var TypeList = []string{"email", "eamil", "date", "phone", "url", "ipv4", "domain", "word", "sentence",
+	"firstname", "lastname", "fullname", "username", "street", "city", "state", "zip", "country",
+	"latitude", "longitude", "company", "jobtitle",
+	"ipv6", "cidr", "mac", "port", "hostname", "uri",
+	"uuid", "ulid", "objectid", "snowflake",
+	"iban", "creditcard", "currency", "amount", "bic",
+	"paragraph", "lorem", "markdown", "html",
+	"bytes", "base64", "hex", "png", "jpeg", "pdf", "csv", "json", "file",
+	"hexcolor", "rgb", "hsl", "semver", "mimetype", "filepath", "useragent", "jwt", "sha256"}
 
-func isInTypeList(s string) bool {
-	for _, v := range TypeList {
-		if v == s {
-			return true
+// typeKinds contains the field types of the built-in types which support not only string
+var typeKinds = map[string][]string{
+	"date":      {"int64", "string"},
+	"latitude":  {"float32", "float64", "string"},
+	"longitude": {"float32", "float64", "string"},
+	"ipv4":      {"string", "net.IP"},
+	"ipv6":      {"string", "net.IP"},
+	"cidr":      {"string", "net.IPNet"},
+	"mac":       {"string", "net.HardwareAddr"},
+	"port":      {"string", "int", "int32", "int64", "uint", "uint16", "uint32", "uint64"},
+	"uuid":      {"string", "array", "slice"},
+	"ulid":      {"string", "array", "slice"},
+	"objectid":  {"string", "array", "slice"},
+	"snowflake": {"string", "int", "int64", "uint", "uint64"},
+	"amount":    {"string", "float32", "float64", "int", "int32", "int64", "uint", "uint32", "uint64"},
+	"bytes":     {"string", "slice", "array"},
+	"base64":    {"string", "slice"},
+	"hex":       {"string", "slice"},
+	"png":       {"string", "slice"},
+	"jpeg":      {"string", "slice"},
+	"pdf":       {"string", "slice"},
+	"csv":       {"string", "slice"},
+	"json":      {"string", "slice"},
+	"file":      {"string", "slice"},
+}
+
+// Charsets contains the built-in charsets of charset tag func
+var Charsets = map[string]string{
+	"alnum":  "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ",
+	"hex":    "0123456789abcdef",
+	"base64": "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/",
+	"lower":  "abcdefghijklmnopqrstuvwxyz",
+	"upper":  "ABCDEFGHIJKLMNOPQRSTUVWXYZ",
+	"digits": "0123456789",
+}
+
+// TagFuncs is the avalid tag funcs
+var TagFuncs = []string{"range", "type", "value", "mock", "valid", "key", "elem", "format", "tag", "precision", "step", "pattern", "tmpl", "charset", "script", "locale", "scheme"}
+
+// splitTag split tags into [name, param] pairs, parentheses in param must be balanced or escaped by '\'
+func splitTag(tags string) [][2]string {
+	var fields [][2]string
+	for i := 0; i < len(tags); i++ {
+		if i > 0 && isLetter(tags[i-1]) {
+			continue
+		}
+		name := ""
+		for _, fn := range TagFuncs {
+			if strings.HasPrefix(tags[i:], fn+"(") {
+				name = fn
+				break
+			}
+		}
+		if name == "" {
+			continue
+		}
+		start := i + len(name) + 1
+		end := closeParen(tags, start, name == "pattern")
+		if end < 0 && name == "pattern" {
+			// unclosed character class, let the regular expression parser report it
+			end = closeParen(tags, start, false)
+		}
+		if end < 0 {
+			break
+		}
+		if end > start {
+			fields = append(fields, [2]string{name, tags[start:end]})
 		}
+		i = end
 	}
-	return false
+	return fields
+}
+
+// closeParen return the index of the parenthesis closing the one before start, or -1.
+// Parentheses inside regular expression character classes are ignored when inClass is true.
+func closeParen(s string, start int, inClass bool) int {
+	depth := 1
+	class := false
+	for i := start; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '\\':
+			i++
+		case class:
+			if c == ']' && s[i-1] != '[' && !(s[i-1] == '^' && s[i-2] == '[') {
+				class = false
+			}
+		case c == '[' && inClass:
+			class = true
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func isLetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
 }
 
 // Tag store the fileds
 type Tag struct {
-	Type      string
-	Values    []interface{}
-	Min       int64 // default 1
-	Max       int64 // default 10
-	Key       string
-	Elem      string
-	Format    string
-	Tag       string
-	GenFunc   string
-	ValidFunc string
+	Type         string
+	Values       []interface{}
+	Min          int64  // default 1
+	Max          int64  // default 10
+	MinUint      uint64 // unsigned bounds, fall back to Min and Max when both are 0
+	MaxUint      uint64
+	MinFloat     float64 // float bounds, fall back to Min and Max when both are 0
+	MaxFloat     float64
+	MinExclusive bool    // range(]min, max...)
+	MaxInclusive bool    // range(...min, max])
+	Step         float64 // quantize floats to multiples of Step, 0 means no quantization
+	Precision    int     // decimal places kept when Step > 0
+	Key          string
+	Elem         string
+	Format       string
+	Tag          string
+	GenFunc      string
+	ValidFunc    string
+	Pattern      string     // regular expression of string
+	Template     string     // string template, e.g. {{word}}-{{range(100,999)}}@{{domain}}
+	Charset      string     // chars of random string, default Chars
+	Scripts      []string   // unicode scripts of random string, range counts runes
+	Marks        bool       // mix combining marks into scripts
+	Edge         bool       // mix edge case runes into scripts
+	Locale       string     // locale name in Locales
+	Subnet       *net.IPNet // subnet of ipv4, ipv6 and cidr, e.g. range(10.0.0.0/8)
+	Schemes      []string   // schemes of uri and url, e.g. scheme(https|wss)
+	MinVersion   string     // version bounds of semver, e.g. range(1.0.0, 2.0.0)
+	MaxVersion   string
+
+	pattern *syntax.Regexp
+	tmpl    []tmplPart
+	scripts []*unicode.RangeTable
+	locale  *Locale
+	ranged  bool // has range tag func, Valid checks the bounds
 }
 
 // DefaultTag return a tag with default value
@@ -89,54 +220,43 @@ func DefaultTag() Tag {
 
 // ParseTag parse string to Tag
 func ParseTag(typ, tags string) (t Tag, err error) {
+	return parseTag(typ, tags, tagContext{})
+}
+
+// tagContext contains the customized funcs and charsets avaliable to parseTag
+type tagContext struct {
+	genFuncs GenFuncs
+	charsets map[string]string
+	locale   string // default locale
+	types    map[string]TypeProvider
+}
+
+// parseTag parse string to Tag in ctx
+func parseTag(typ, tags string, ctx tagContext) (t Tag, err error) {
 	t = DefaultTag()
 	if tags == "" {
 		return t, nil
 	}
 
-	re := regexp.MustCompile(`(range|type|value|mock|valid|key|elem|format|tag)\((.+?)\)`)
-	fields := re.FindAllStringSubmatch(tags, -1)
-	for _, f := range fields {
-		switch f[1] {
+	var precision, step string
+	for _, f := range splitTag(tags) {
+		switch f[0] {
 		case "range":
-			vals := strings.Split(f[2], ",")
-			if len(vals) == 1 {
-				if v, err := strconv.ParseInt(strings.TrimSpace(vals[0]), 10, 64); err == nil {
-					if v < t.Min {
-						t.Min = v
-						t.Max = 1
-					} else {
-						t.Max = v
-					}
-				} else {
-					return DefaultTag(), NewParamError(f[1], "number", vals[0])
-				}
-			} else if len(vals) == 2 {
-				if t.Min, err = strconv.ParseInt(strings.TrimSpace(vals[0]), 10, 64); err != nil {
-					return DefaultTag(), NewParamError(f[1], "number", vals[0])
-				}
-				if t.Max, err = strconv.ParseInt(strings.TrimSpace(vals[1]), 10, 64); err != nil {
-					return DefaultTag(), NewParamError(f[1], "number", vals[1])
-				}
-				if t.Min > t.Max {
-					return DefaultTag(), NewParamError(f[1], "min <= max", fmt.Sprintf("min: %d > max: %d", t.Min, t.Max))
-				}
-			} else {
-				return DefaultTag(), NewParamError(f[1], "one or two number", len(vals))
+			if err = parseRange(&t, typ, f[1]); err != nil {
+				return DefaultTag(), err
 			}
+			t.ranged = true
 		case "type":
-			if !isInTypeList(f[2]) {
-				return DefaultTag(), NewParamError(f[1], strings.Join(TypeList, "/"), f[2])
+			p, ok := ctx.typeProvider(f[1])
+			if !ok {
+				return DefaultTag(), NewParamError(f[0], strings.Join(ctx.typeNames(), "/"), f[1])
 			}
-			if f[2] == "date" && typ != "int64" && typ != "string" {
-				return DefaultTag(), NewConflictError("fieldType", typ, f[1], f[2], "date need field type int64 or string")
+			if kinds := p.Kinds(); !contains(kinds, typ) {
+				return DefaultTag(), NewConflictError("fieldType", typ, f[0], f[1], fmt.Sprintf("%s need field type %s", f[1], strings.Join(kinds, " or ")))
 			}
-			if f[2] != "date" && typ != "string" {
-				return DefaultTag(), NewConflictError("fieldType", typ, f[1], f[2], fmt.Sprintf("%s need field type string", f[2]))
-			}
-			t.Type = f[2]
+			t.Type = f[1]
 		case "value":
-			vals := strings.Split(f[2], ",")
+			vals := strings.Split(f[1], ",")
 			t.Values = make([]interface{}, 0, len(vals))
 			for i, v := range vals {
 				vals[i] = strings.TrimSpace(v)
@@ -149,31 +269,31 @@ func ParseTag(typ, tags string) (t Tag, err error) {
 			case strings.HasPrefix(typ, "int"):
 				for _, v := range vals {
 					var n int64
-					if n, err = strconv.ParseInt(v, 10, 64); err != nil {
-						return DefaultTag(), NewConflictError("fieldType", typ, f[1], v, err.Error())
+					if n, err = strconv.ParseInt(v, 10, bitSize(typ)); err != nil {
+						return DefaultTag(), NewConflictError("fieldType", typ, f[0], v, err.Error())
 					}
 					t.Values = append(t.Values, n)
 				}
 			case strings.HasPrefix(typ, "uint"):
 				for _, v := range vals {
 					var n uint64
-					if n, err = strconv.ParseUint(v, 10, 64); err != nil {
-						return DefaultTag(), NewConflictError("fieldType", typ, f[1], v, err.Error())
+					if n, err = strconv.ParseUint(v, 10, bitSize(typ)); err != nil {
+						return DefaultTag(), NewConflictError("fieldType", typ, f[0], v, err.Error())
 					}
 					t.Values = append(t.Values, n)
 				}
 			case strings.HasPrefix(typ, "float"):
 				for _, v := range vals {
 					var n float64
-					if n, err = strconv.ParseFloat(v, 64); err != nil {
-						return DefaultTag(), NewConflictError("fieldType", typ, f[1], v, err.Error())
+					if n, err = strconv.ParseFloat(v, floatSize(typ)); err != nil {
+						return DefaultTag(), NewConflictError("fieldType", typ, f[0], v, err.Error())
 					}
 					t.Values = append(t.Values, n)
 				}
 			case typ == "bool":
 				for _, v := range vals {
 					if v != "true" && v != "false" {
-						return DefaultTag(), NewConflictError("fieldType", typ, f[1], v, err.Error())
+						return DefaultTag(), NewConflictError("fieldType", typ, f[0], v, "bool need true or false")
 					}
 					n := false
 					if v == "true" {
@@ -183,17 +303,86 @@ func ParseTag(typ, tags string) (t Tag, err error) {
 				}
 			}
 		case "mock":
-			t.GenFunc = f[2]
+			t.GenFunc = f[1]
 		case "valid":
-			t.ValidFunc = f[2]
+			t.ValidFunc = f[1]
 		case "key":
-			t.Key = f[2]
+			t.Key = f[1]
 		case "elem":
-			t.Elem = f[2]
+			t.Elem = f[1]
 		case "format":
-			t.Format = f[2]
+			t.Format = f[1]
 		case "tag":
-			t.Tag = f[2]
+			t.Tag = f[1]
+		case "precision":
+			precision = strings.TrimSpace(f[1])
+		case "step":
+			step = strings.TrimSpace(f[1])
+		case "pattern":
+			if typ != "string" {
+				return DefaultTag(), NewConflictError("fieldType", typ, f[0], f[1], "pattern need field type string")
+			}
+			re, err := syntax.Parse(f[1], syntax.Perl)
+			if err != nil {
+				return DefaultTag(), NewParamError(f[0], "regular expression", f[1])
+			}
+			t.Pattern, t.pattern = f[1], re
+		case "tmpl":
+			if typ != "string" {
+				return DefaultTag(), NewConflictError("fieldType", typ, f[0], f[1], "tmpl need field type string")
+			}
+			if t.tmpl, err = parseTmpl(f[1], ctx); err != nil {
+				return DefaultTag(), err
+			}
+			t.Template = f[1]
+		case "charset":
+			if typ != "string" {
+				return DefaultTag(), NewConflictError("fieldType", typ, f[0], f[1], "charset need field type string")
+			}
+			if t.Charset, err = parseCharset(f[1], ctx); err != nil {
+				return DefaultTag(), err
+			}
+		case "script":
+			if typ != "string" {
+				return DefaultTag(), NewConflictError("fieldType", typ, f[0], f[1], "script need field type string")
+			}
+			if err = parseScript(&t, f[1]); err != nil {
+				return DefaultTag(), err
+			}
+		case "locale":
+			t.Locale = strings.TrimSpace(f[1])
+		case "scheme":
+			for _, scheme := range strings.Split(f[1], "|") {
+				t.Schemes = append(t.Schemes, strings.TrimSpace(scheme))
+			}
+		}
+	}
+	if err = checkNet(&t); err != nil {
+		return DefaultTag(), err
+	}
+	if err = checkFinance(&t); err != nil {
+		return DefaultTag(), err
+	}
+	if err = checkBlob(&t); err != nil {
+		return DefaultTag(), err
+	}
+	if err = checkDev(&t); err != nil {
+		return DefaultTag(), err
+	}
+	if t.Locale == "" {
+		t.Locale = ctx.locale
+	}
+	if t.Locale != "" {
+		if t.locale, err = parseLocale(t.Locale); err != nil {
+			return DefaultTag(), err
+		}
+	}
+	if precision != "" || step != "" {
+		if !strings.HasPrefix(typ, "float") {
+			return DefaultTag(), NewConflictError("fieldType", typ, "precision/step", precision+step, "precision and step need field type float")
+		}
+		if err = parseStep(&t, precision, step); err != nil {
+			return DefaultTag(), err
 		}
 	}
 	if t.Min < 0 && (t.Type == "word" || t.Type == "sentence") {
@@ -204,3 +393,379 @@ func ParseTag(typ, tags string) (t Tag, err error) {
 	}
 	return t, nil
 }
+
+// parseRange parse the range tag func, a bound prefixed with ']' is exclusive and a bound
+// suffixed with ']' is inclusive, e.g. range([0.01, 99.99]) and range(]0, 1[).
+// Numeric fields accept open bounds, range(min,) and range(,max), and all integer bounds
+// are clamped to the bit size of the field type.
+func parseRange(t *Tag, typ, param string) error {
+	if strings.Contains(param, "/") {
+		return parseSubnet(t, param)
+	}
+	vals := strings.Split(param, ",")
+	if len(vals) > 2 {
+		return NewParamError("range", "one or two number", len(vals))
+	}
+	lo, hi := strings.TrimSpace(vals[0]), "1"
+	if len(vals) == 2 {
+		hi = strings.TrimSpace(vals[1])
+		t.MinExclusive = strings.HasPrefix(lo, "]")
+		t.MaxInclusive = strings.HasSuffix(hi, "]")
+		lo = strings.TrimSpace(strings.Trim(lo, "[]"))
+		hi = strings.TrimSpace(strings.Trim(hi, "[]"))
+		if lo == "" && hi == "" {
+			return NewParamError("range", "at least one bound", param)
+		}
+		if hi == "" {
+			t.MaxInclusive = true
+		}
+	}
+	if (lo == "" || hi == "") && !isNumber(typ) {
+		return NewConflictError("fieldType", typ, "range", param, "open bound need a number field")
+	}
+	if isVersionRange(lo, hi) {
+		return parseVersionRange(t, lo, hi, len(vals) == 1)
+	}
+
+	switch {
+	case strings.HasPrefix(typ, "float"):
+		return parseFloatRange(t, typ, lo, hi, len(vals) == 1)
+	case strings.HasPrefix(typ, "uint"):
+		return parseUintRange(t, typ, lo, hi, len(vals) == 1)
+	}
+
+	bits := bitSize(typ)
+	dmin, dmax := int64(math.MinInt64)>>uint(64-bits), int64(math.MaxInt64)>>uint(64-bits)
+	min, max := dmin, dmax
+	var err error
+	if lo != "" {
+		if min, err = strconv.ParseInt(lo, 10, bits); err != nil && !isRangeError(err) {
+			return NewParamError("range", "number", lo)
+		}
+		if err != nil && min == dmax && len(vals) == 2 {
+			return rangeConflict(typ, lo, hi)
+		}
+		t.MinExclusive = t.MinExclusive && err == nil
+	}
+	if hi != "" {
+		if max, err = strconv.ParseInt(hi, 10, bits); err != nil && !isRangeError(err) {
+			return NewParamError("range", "number", hi)
+		}
+		if err != nil && max == dmin {
+			return rangeConflict(typ, lo, hi)
+		}
+		// a clamped max is reachable
+		t.MaxInclusive = t.MaxInclusive || err != nil
+	}
+	if len(vals) == 1 && min >= 1 {
+		min, max = 1, min
+	}
+	if min > max {
+		return NewParamError("range", "min <= max", fmt.Sprintf("min: %d > max: %d", min, max))
+	}
+	t.Min, t.Max = min, max
+	return nil
+}
+
+func parseUintRange(t *Tag, typ, lo, hi string, single bool) error {
+	bits := bitSize(typ)
+	// parse return the bound clamped to [0, max of typ] and whether it was clamped
+	parse := func(s string) (uint64, bool, error) {
+		n, err := strconv.ParseUint(s, 10, bits)
+		if err != nil && strings.HasPrefix(s, "-") {
+			if _, e := strconv.ParseInt(s, 10, 64); e == nil || isRangeError(e) {
+				return 0, true, nil
+			}
+		}
+		if err != nil && !isRangeError(err) {
+			return 0, false, NewParamError("range", "number", s)
+		}
+		return n, err != nil, nil
+	}
+
+	dmax := uint64(math.MaxUint64) >> uint(64-bits)
+	min, max := uint64(0), dmax
+	var clamped bool
+	var err error
+	if lo != "" {
+		if min, clamped, err = parse(lo); err != nil {
+			return err
+		}
+		if clamped && min == dmax && !single {
+			return rangeConflict(typ, lo, hi)
+		}
+		t.MinExclusive = t.MinExclusive && !clamped
+	}
+	if hi != "" {
+		if max, clamped, err = parse(hi); err != nil {
+			return err
+		}
+		if clamped && max == 0 && !single {
+			return rangeConflict(typ, lo, hi)
+		}
+		t.MaxInclusive = t.MaxInclusive || clamped
+	}
+	if single && min >= 1 {
+		min, max = 1, min
+	}
+	if min > max {
+		return NewParamError("range", "min <= max", fmt.Sprintf("min: %d > max: %d", min, max))
+	}
+	t.MinUint, t.MaxUint = min, max
+	t.Min, t.Max = clampInt64(min), clampInt64(max)
+	return nil
+}
+
+func parseFloatRange(t *Tag, typ, lo, hi string, single bool) error {
+	limit := math.MaxFloat64
+	if floatSize(typ) == 32 {
+		limit = math.MaxFloat32
+	}
+	min, max := -limit, limit
+	var err error
+	if lo != "" {
+		if min, err = strconv.ParseFloat(lo, 64); err != nil {
+			return NewParamError("range", "number", lo)
+		}
+	}
+	if hi != "" {
+		if max, err = strconv.ParseFloat(hi, 64); err != nil {
+			return NewParamError("range", "number", hi)
+		}
+	}
+	if single && min >= 1 {
+		min, max = 1, min
+	}
+	if min > max {
+		return NewParamError("range", "min <= max", fmt.Sprintf("min: %v > max: %v", min, max))
+	}
+	if min > limit || max < -limit {
+		return rangeConflict(typ, lo, hi)
+	}
+	t.MinFloat, t.MaxFloat = math.Max(min, -limit), math.Min(max, limit)
+	t.Min, t.Max = int64(math.Max(math.Floor(min), math.MinInt64)), int64(math.Min(math.Ceil(max), math.MaxInt64))
+	return nil
+}
+
+func rangeConflict(typ, lo, hi string) error {
+	return NewConflictError("fieldType", typ, "range", lo+","+hi, fmt.Sprintf("range out of %s bounds", typ))
+}
+
+// isNumber report whether typ is a int, uint or float kind
+func isNumber(typ string) bool {
+	return strings.HasPrefix(typ, "int") || strings.HasPrefix(typ, "uint") || strings.HasPrefix(typ, "float")
+}
+
+// bitSize return the bit size of a int or uint kind, default 64
+func bitSize(typ string) int {
+	typ = strings.TrimPrefix(strings.TrimPrefix(typ, "u"), "int")
+	switch typ {
+	case "8", "16", "32", "64":
+		n, _ := strconv.Atoi(typ)
+		return n
+	case "", "ptr":
+		return strconv.IntSize
+	}
+	return 64
+}
+
+// floatSize return the bit size of a float kind
+func floatSize(typ string) int {
+	if typ == "float32" {
+		return 32
+	}
+	return 64
+}
+
+func isRangeError(err error) bool {
+	e, ok := err.(*strconv.NumError)
+	return ok && e.Err == strconv.ErrRange
+}
+
+func clampInt64(n uint64) int64 {
+	if n > math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return int64(n)
+}
+
+// parseStep parse the precision and step tag funcs
+func parseStep(t *Tag, precision, step string) error {
+	if precision != "" {
+		n, err := strconv.Atoi(precision)
+		if err != nil || n < 0 {
+			return NewParamError("precision", "non-negative integer", precision)
+		}
+		t.Precision = n
+		t.Step = math.Pow10(-n)
+	}
+	if step != "" {
+		v, err := strconv.ParseFloat(step, 64)
+		if err != nil || v <= 0 {
+			return NewParamError("step", "positive number", step)
+		}
+		t.Step = v
+		if n := decimals(step); precision == "" || n > t.Precision {
+			t.Precision = n
+		}
+	}
+	return nil
+}
+
+// decimals return the count of decimal places of a number string
+func decimals(s string) int {
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		exp, _ := strconv.Atoi(s[i+1:])
+		n := decimals(s[:i]) - exp
+		if n < 0 {
+			return 0
+		}
+		return n
+	}
+	if i := strings.Index(s, "."); i >= 0 {
+		return len(s) - i - 1
+	}
+	return 0
+}
+
+// intBounds return the inclusive bounds of integer and length
+func (t Tag) intBounds() (lo, hi int64) {
+	lo, hi = t.Min, t.Max
+	if t.MinExclusive && lo < math.MaxInt64 {
+		lo++
+	}
+	if !t.MaxInclusive && hi > math.MinInt64 {
+		hi--
+	}
+	if hi < lo {
+		hi = lo
+	}
+	return lo, hi
+}
+
+// uintBounds return the inclusive bounds of unsigned integer, fall back to Min and Max
+func (t Tag) uintBounds() (lo, hi uint64) {
+	lo, hi = t.MinUint, t.MaxUint
+	if lo == 0 && hi == 0 {
+		if t.Min > 0 {
+			lo = uint64(t.Min)
+		}
+		if t.Max > 0 {
+			hi = uint64(t.Max)
+		}
+	}
+	if t.MinExclusive && lo < math.MaxUint64 {
+		lo++
+	}
+	if !t.MaxInclusive && hi > 0 {
+		hi--
+	}
+	if hi < lo {
+		hi = lo
+	}
+	return lo, hi
+}
+
+// floatBounds return the float bounds, fall back to Min and Max
+func (t Tag) floatBounds() (min, max float64) {
+	if t.MinFloat == 0 && t.MaxFloat == 0 {
+		return float64(t.Min), float64(t.Max)
+	}
+	return t.MinFloat, t.MaxFloat
+}
+
+// tmplPart is a literal text or a placeholder of tmpl
+type tmplPart struct {
+	text   string
+	name   string // seq or GenFunc name
+	format string // date format or fmt verb
+	tag    *Tag   // built-in generator
+	str    bool   // generate string by tag, otherwise int
+}
+
+// parseTmpl parse the tmpl tag func, placeholders are:
+//
+//	{{seq}}: a increasing sequence number
+//	{{type}}, {{type|format}}: a built-in type, e.g. {{word}}, {{date|20060102}}
+//	{{name}}, {{name|verb}}: a registered GenFunc, formatted by fmt verb
+//	{{tags}}, {{tags|verb}}: a int by tags, or a string if tags has type, pattern or value,
+//	e.g. {{range(100,999)}}, {{pattern([A-Z]{2})}}
+func parseTmpl(s string, ctx tagContext) ([]tmplPart, error) {
+	var parts []tmplPart
+	for s != "" {
+		i := strings.Index(s, "{{")
+		if i < 0 {
+			parts = append(parts, tmplPart{text: s})
+			break
+		}
+		if i > 0 {
+			parts = append(parts, tmplPart{text: s[:i]})
+		}
+		j := strings.Index(s[i:], "}}")
+		if j < 0 {
+			return nil, NewParamError("tmpl", "closed placeholder", s[i:])
+		}
+		p, err := parsePlaceholder(strings.TrimSpace(s[i+2:i+j]), ctx)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, p)
+		s = s[i+j+2:]
+	}
+	return parts, nil
+}
+
+func parsePlaceholder(s string, ctx tagContext) (tmplPart, error) {
+	var p tmplPart
+	// the format follows the last '|' out of the tag funcs
+	if i := strings.LastIndex(s, "|"); i > strings.LastIndex(s, ")") {
+		s, p.format = strings.TrimSpace(s[:i]), strings.TrimSpace(s[i+1:])
+	}
+
+	switch {
+	case strings.Contains(s, "("):
+		typ := "int64"
+		for _, fn := range []string{"type(", "pattern(", "value("} {
+			if strings.Contains(s, fn) {
+				typ, p.str = "string", true
+			}
+		}
+		t, err := parseTag(typ, s, ctx)
+		if err != nil {
+			return p, err
+		}
+		p.tag = &t
+	case ctx.isType(s):
+		t, _ := parseTag("string", "type("+s+")", ctx)
+		t.Format = p.format
+		p.tag, p.str, p.format = &t, true, ""
+	case s == "seq":
+		p.name = s
+	default:
+		if _, ok := ctx.genFuncs[s]; !ok {
+			return p, NewParamError("tmpl", "built-in type, seq or registered GenFunc", s)
+		}
+		p.name = s
+	}
+	return p, nil
+}
+
+// parseCharset parse the charset tag func, param is a quoted string of chars,
+// or a charset name in ctx or Charsets
+func parseCharset(param string, ctx tagContext) (string, error) {
+	param = strings.TrimSpace(param)
+	if strings.HasPrefix(param, `"`) {
+		chars, err := strconv.Unquote(param)
+		if err != nil || chars == "" {
+			return "", NewParamError("charset", "quoted chars", param)
+		}
+		return chars, nil
+	}
+	if chars, ok := ctx.charsets[param]; ok && chars != "" {
+		return chars, nil
+	}
+	if chars, ok := Charsets[param]; ok {
+		return chars, nil
+	}
+	return "", NewParamError("charset", "charset name or quoted chars", param)
+}
@@ -0,0 +1,338 @@
+// Package protomock populates protobuf messages by protoreflect, the fields are generated
+// by a mock.Mocker with the tags of the (mock.tag) field option or a map of field paths
+package protomock
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+
+	"github.com/CJH9004/mock"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// TagField is the field number of the tag field option in mock.proto:
+//
+//	extend google.protobuf.FieldOptions { string tag = 50505; }
+const TagField protowire.Number = 50505
+
+// MaxDepth is the depth of nested messages beyond which the message fields are left unset,
+// to end the recursive messages
+const MaxDepth = 8
+
+// indexRe matches the list and map indexes of a path
+var indexRe = regexp.MustCompile(`\[[^\]]*\]`)
+
+// wrappers are the well-known wrapper messages, the tags of the field apply to their value
+var wrappers = map[protoreflect.FullName]bool{
+	"google.protobuf.DoubleValue": true,
+	"google.protobuf.FloatValue":  true,
+	"google.protobuf.Int64Value":  true,
+	"google.protobuf.UInt64Value": true,
+	"google.protobuf.Int32Value":  true,
+	"google.protobuf.UInt32Value": true,
+	"google.protobuf.BoolValue":   true,
+	"google.protobuf.StringValue": true,
+	"google.protobuf.BytesValue":  true,
+}
+
+// unset are the well-known messages left empty
+var unset = map[protoreflect.FullName]bool{
+	"google.protobuf.Any":       true,
+	"google.protobuf.Struct":    true,
+	"google.protobuf.Value":     true,
+	"google.protobuf.ListValue": true,
+	"google.protobuf.FieldMask": true,
+	"google.protobuf.Empty":     true,
+}
+
+type populator struct {
+	m      mock.Mocker
+	fields map[string]string
+}
+
+// Mock populate the unset fields of msg by m, the tags of a field are the value of fields by path,
+// e.g. {"items.sku": "pattern([A-Z]{3})"}, or the (mock.tag) option of the field, the indexes of
+// paths are ignored. One field of each oneof is chosen, enums take their defined numbers,
+// Timestamp is the clock of m, Duration is in [0, 86400) seconds by default,
+// and the tags of wrapper fields apply to the value
+func Mock(m mock.Mocker, msg proto.Message, fields map[string]string) error {
+	p := populator{m: m, fields: fields}
+	return p.message("", "", msg.ProtoReflect(), 0)
+}
+
+func (p populator) message(path, tags string, msg protoreflect.Message, depth int) error {
+	md := msg.Descriptor()
+	switch name := md.FullName(); {
+	case name == "google.protobuf.Timestamp":
+		return p.timestamp(tags, msg)
+	case name == "google.protobuf.Duration":
+		return p.duration(tags, msg)
+	case wrappers[name]:
+		fd := md.Fields().ByName("value")
+		v, err := p.value(path, tags, fd, depth, nil)
+		if err == nil {
+			msg.Set(fd, v)
+		}
+		return err
+	case unset[name]:
+		return nil
+	}
+
+	chosen := map[protoreflect.FullName]protoreflect.FieldDescriptor{}
+	oneofs := md.Oneofs()
+	for i := 0; i < oneofs.Len(); i++ {
+		od := oneofs.Get(i)
+		if !od.IsSynthetic() {
+			chosen[od.FullName()] = od.Fields().Get(p.intn(od.Fields().Len()))
+		}
+	}
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if od := fd.ContainingOneof(); od != nil && !od.IsSynthetic() && chosen[od.FullName()] != fd {
+			continue
+		}
+		name := joinPath(path, string(fd.Name()))
+		tags := p.tags(name, fd)
+		if tags == "-" || msg.Has(fd) || depth >= MaxDepth && hasMessage(fd) {
+			continue
+		}
+		if err := p.field(name, tags, msg, fd, depth); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p populator) field(path, tags string, msg protoreflect.Message, fd protoreflect.FieldDescriptor, depth int) error {
+	switch {
+	case fd.IsList():
+		t, n, err := p.length(tags)
+		if err != nil {
+			return err
+		}
+		list := msg.Mutable(fd).List()
+		for i := 0; i < n; i++ {
+			v, err := p.value(fmt.Sprintf("%s[%d]", path, i), t.Elem, fd, depth, list.NewElement)
+			if err != nil {
+				return err
+			}
+			list.Append(v)
+		}
+	case fd.IsMap():
+		t, n, err := p.length(tags)
+		if err != nil {
+			return err
+		}
+		keyTags := t.Key
+		if keyTags == "" && fd.MapKey().Kind() == protoreflect.StringKind {
+			keyTags = "type(word)"
+		}
+		mp := msg.Mutable(fd).Map()
+		// regenerate the duplicate keys, at most UniqueRetry times per entry
+		for i := 0; mp.Len() < n && i < n*mock.UniqueRetry; i++ {
+			key, err := p.value(path, keyTags, fd.MapKey(), depth, nil)
+			if err != nil {
+				return err
+			}
+			v, err := p.value(fmt.Sprintf("%s[%v]", path, key), t.Elem, fd.MapValue(), depth, mp.NewValue)
+			if err != nil {
+				return err
+			}
+			mp.Set(key.MapKey(), v)
+		}
+	default:
+		v, err := p.value(path, tags, fd, depth, func() protoreflect.Value { return msg.NewField(fd) })
+		if err != nil {
+			return err
+		}
+		msg.Set(fd, v)
+	}
+	return nil
+}
+
+// value return a value of the singular kind of fd, newMessage return a new message of the message kinds
+func (p populator) value(path, tags string, fd protoreflect.FieldDescriptor, depth int, newMessage func() protoreflect.Value) (protoreflect.Value, error) {
+	var v interface{}
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		msg := newMessage()
+		return msg, p.message(path, tags, msg.Message(), depth+1)
+	case protoreflect.EnumKind:
+		return p.enum(tags, fd.Enum())
+	case protoreflect.BoolKind:
+		v = new(bool)
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		v = new(int32)
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		v = new(int64)
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		v = new(uint32)
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		v = new(uint64)
+	case protoreflect.FloatKind:
+		v = new(float32)
+	case protoreflect.DoubleKind:
+		v = new(float64)
+	case protoreflect.StringKind:
+		v = new(string)
+	case protoreflect.BytesKind:
+		v = new([]byte)
+	}
+	if err := p.m.Mock(tags, v); err != nil {
+		return protoreflect.Value{}, err
+	}
+	return protoreflect.ValueOf(reflect.ValueOf(v).Elem().Interface()), nil
+}
+
+// enum return a random number defined by ed, value tag func restricts the names or numbers
+func (p populator) enum(tags string, ed protoreflect.EnumDescriptor) (protoreflect.Value, error) {
+	t, err := mock.ParseTag("string", tags)
+	if err != nil {
+		return protoreflect.Value{}, err
+	}
+	var nums []protoreflect.EnumNumber
+	values := ed.Values()
+	for i := 0; i < values.Len(); i++ {
+		ev := values.Get(i)
+		if len(t.Values) == 0 || contains(t.Values, string(ev.Name())) || contains(t.Values, fmt.Sprint(ev.Number())) {
+			nums = append(nums, ev.Number())
+		}
+	}
+	if len(nums) == 0 {
+		return protoreflect.Value{}, mock.NewConflictError("enum", ed.FullName(), "value", t.Values, "value need be the names or numbers of the enum")
+	}
+	return protoreflect.ValueOfEnum(nums[p.intn(len(nums))]), nil
+}
+
+// timestamp set msg to the time generated by tags, default type(date)
+func (p populator) timestamp(tags string, msg protoreflect.Message) error {
+	if tags == "" {
+		tags = "type(date)"
+	}
+	return p.secondsNanos(tags, msg)
+}
+
+// duration set msg to the duration generated by tags in seconds, default range(0, 86400)
+func (p populator) duration(tags string, msg protoreflect.Message) error {
+	if tags == "" {
+		tags = "range(0, 86400)"
+	}
+	return p.secondsNanos(tags, msg)
+}
+
+// secondsNanos set the seconds of msg by tags and the nanos of the same sign randomly
+func (p populator) secondsNanos(tags string, msg protoreflect.Message) error {
+	var seconds int64
+	var nanos int32
+	if err := p.m.Mock(tags, &seconds); err != nil {
+		return err
+	}
+	if err := p.m.Mock("range(0, 999999999])", &nanos); err != nil {
+		return err
+	}
+	if seconds < 0 {
+		nanos = -nanos
+	}
+	fields := msg.Descriptor().Fields()
+	msg.Set(fields.ByName("seconds"), protoreflect.ValueOfInt64(seconds))
+	msg.Set(fields.ByName("nanos"), protoreflect.ValueOfInt32(nanos))
+	return nil
+}
+
+// length return the tag of the list or map field and a random length in its range
+func (p populator) length(tags string) (mock.Tag, int, error) {
+	t, err := mock.ParseTag("slice", tags)
+	if err != nil {
+		return t, 0, err
+	}
+	lo, hi := t.Min, t.Max
+	if t.MinExclusive {
+		lo++
+	}
+	if !t.MaxInclusive {
+		hi--
+	}
+	if hi < lo {
+		hi = lo
+	}
+	var n int
+	err = p.m.Mock(fmt.Sprintf("range(%d, %d])", lo, hi), &n)
+	return t, n, err
+}
+
+// tags return the tags of the field fd at path, the tags in fields override the field option
+func (p populator) tags(path string, fd protoreflect.FieldDescriptor) string {
+	if tags, ok := p.fields[indexRe.ReplaceAllString(path, "")]; ok {
+		return tags
+	}
+	return fieldOption(fd)
+}
+
+// fieldOption return the (mock.tag) option of fd, the option is read from the unknown
+// fields of the options when mock.proto is not registered
+func fieldOption(fd protoreflect.FieldDescriptor) string {
+	opts := fd.Options()
+	if opts == nil || !opts.ProtoReflect().IsValid() {
+		return ""
+	}
+	m := opts.ProtoReflect()
+	var tag string
+	m.Range(func(f protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if f.IsExtension() && f.Number() == TagField && f.Kind() == protoreflect.StringKind {
+			tag = v.String()
+			return false
+		}
+		return true
+	})
+	for b := m.GetUnknown(); tag == "" && len(b) > 0; {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			break
+		}
+		b = b[n:]
+		if num == TagField && typ == protowire.BytesType {
+			v, _ := protowire.ConsumeBytes(b)
+			return string(v)
+		}
+		if n = protowire.ConsumeFieldValue(num, typ, b); n < 0 {
+			break
+		}
+		b = b[n:]
+	}
+	return tag
+}
+
+// intn return a random int in [0, n)
+func (p populator) intn(n int) int {
+	var i int
+	p.m.Mock(fmt.Sprintf("range(0, %d)", n), &i)
+	return i
+}
+
+// hasMessage report whether the values of fd are messages
+func hasMessage(fd protoreflect.FieldDescriptor) bool {
+	if fd.IsMap() {
+		fd = fd.MapValue()
+	}
+	return fd.Message() != nil
+}
+
+func contains(values []interface{}, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
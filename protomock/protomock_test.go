@@ -0,0 +1,198 @@
+package protomock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/CJH9004/mock"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	_ "google.golang.org/protobuf/types/known/timestamppb"
+	_ "google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// tagOption return the field options with the (mock.tag) option as unknown fields
+func tagOption(tags string) *descriptorpb.FieldOptions {
+	opts := &descriptorpb.FieldOptions{}
+	b := protowire.AppendTag(nil, TagField, protowire.BytesType)
+	opts.ProtoReflect().SetUnknown(protowire.AppendString(b, tags))
+	return opts
+}
+
+func field(name string, number int32, typ descriptorpb.FieldDescriptorProto_Type, typeName string, opts *descriptorpb.FieldOptions) *descriptorpb.FieldDescriptorProto {
+	f := &descriptorpb.FieldDescriptorProto{
+		Name:     proto.String(name),
+		JsonName: proto.String(name),
+		Number:   proto.Int32(number),
+		Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		Type:     typ.Enum(),
+		Options:  opts,
+	}
+	if typeName != "" {
+		f.TypeName = proto.String(typeName)
+	}
+	return f
+}
+
+func repeated(f *descriptorpb.FieldDescriptorProto) *descriptorpb.FieldDescriptorProto {
+	f.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+	return f
+}
+
+func oneof(f *descriptorpb.FieldDescriptorProto) *descriptorpb.FieldDescriptorProto {
+	f.OneofIndex = proto.Int32(0)
+	return f
+}
+
+// orderDescriptor build the message:
+//
+//	message Order {
+//	  int64 id = 1 [(mock.tag) = "range(100, 200)"];
+//	  string email = 2 [(mock.tag) = "type(email)"];
+//	  Status status = 3;
+//	  repeated Item items = 4;
+//	  map<string, int32> counts = 5;
+//	  oneof payment { string card = 6; int32 points = 7; }
+//	  google.protobuf.Timestamp created = 8;
+//	  google.protobuf.Duration ttl = 9;
+//	  google.protobuf.StringValue note = 10 [(mock.tag) = "value(a, b)"];
+//	  Order parent = 11;
+//	  bytes raw = 12;
+//	  float score = 13;
+//	}
+func orderDescriptor(t *testing.T) protoreflect.MessageDescriptor {
+	file := &descriptorpb.FileDescriptorProto{
+		Name:       proto.String("order.proto"),
+		Package:    proto.String("shop"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{"google/protobuf/timestamp.proto", "google/protobuf/duration.proto", "google/protobuf/wrappers.proto"},
+		EnumType: []*descriptorpb.EnumDescriptorProto{{
+			Name: proto.String("Status"),
+			Value: []*descriptorpb.EnumValueDescriptorProto{
+				{Name: proto.String("UNKNOWN"), Number: proto.Int32(0)},
+				{Name: proto.String("ACTIVE"), Number: proto.Int32(1)},
+				{Name: proto.String("DELETED"), Number: proto.Int32(5)},
+			},
+		}},
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("Item"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				field("sku", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, "", nil),
+				field("qty", 2, descriptorpb.FieldDescriptorProto_TYPE_UINT32, "", tagOption("range(1, 10)")),
+			},
+		}, {
+			Name: proto.String("Order"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				field("id", 1, descriptorpb.FieldDescriptorProto_TYPE_INT64, "", tagOption("range(100, 200)")),
+				field("email", 2, descriptorpb.FieldDescriptorProto_TYPE_STRING, "", tagOption("type(email)")),
+				field("status", 3, descriptorpb.FieldDescriptorProto_TYPE_ENUM, ".shop.Status", nil),
+				repeated(field("items", 4, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".shop.Item", nil)),
+				repeated(field("counts", 5, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".shop.Order.CountsEntry", nil)),
+				oneof(field("card", 6, descriptorpb.FieldDescriptorProto_TYPE_STRING, "", nil)),
+				oneof(field("points", 7, descriptorpb.FieldDescriptorProto_TYPE_INT32, "", nil)),
+				field("created", 8, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".google.protobuf.Timestamp", nil),
+				field("ttl", 9, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".google.protobuf.Duration", nil),
+				field("note", 10, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".google.protobuf.StringValue", tagOption("value(a, b)")),
+				field("parent", 11, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".shop.Order", nil),
+				field("raw", 12, descriptorpb.FieldDescriptorProto_TYPE_BYTES, "", nil),
+				field("score", 13, descriptorpb.FieldDescriptorProto_TYPE_FLOAT, "", nil),
+			},
+			OneofDecl: []*descriptorpb.OneofDescriptorProto{{Name: proto.String("payment")}},
+			NestedType: []*descriptorpb.DescriptorProto{{
+				Name: proto.String("CountsEntry"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("key", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, "", nil),
+					field("value", 2, descriptorpb.FieldDescriptorProto_TYPE_INT32, "", nil),
+				},
+				Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+			}},
+		}},
+	}
+	fd, err := protodesc.NewFile(file, protoregistry.GlobalFiles)
+	if !assert.Nil(t, err) {
+		t.FailNow()
+	}
+	return fd.Messages().ByName("Order")
+}
+
+func TestMock(t *testing.T) {
+	md := orderDescriptor(t)
+	fields := md.Fields()
+	get := func(msg protoreflect.Message, name string) protoreflect.Value {
+		return msg.Get(fields.ByName(protoreflect.Name(name)))
+	}
+	now := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	m := mock.New(time.Now().UnixNano(), &mock.Options{Now: func() time.Time { return now }})
+	tags := map[string]string{
+		"items":     "range(2, 3])",
+		"items.sku": "pattern([A-Z]{3})",
+		"counts":    "range(2, 2]) elem(range(1, 5))",
+		"status":    "value(ACTIVE, 5)",
+		"parent":    "-",
+	}
+
+	payments := map[string]bool{}
+	for i := 0; i < 30; i++ {
+		msg := dynamicpb.NewMessage(md)
+		assert.Nil(t, Mock(m, msg, tags))
+		id := get(msg, "id").Int()
+		assert.True(t, id >= 100 && id < 200, id)
+		assert.Contains(t, get(msg, "email").String(), "@")
+		assert.Contains(t, []protoreflect.EnumNumber{1, 5}, get(msg, "status").Enum())
+
+		items := get(msg, "items").List()
+		assert.True(t, items.Len() >= 2 && items.Len() <= 3)
+		for j := 0; j < items.Len(); j++ {
+			item := items.Get(j).Message()
+			itemFields := item.Descriptor().Fields()
+			assert.Regexp(t, `^[A-Z]{3}$`, item.Get(itemFields.ByName("sku")).String())
+			qty := item.Get(itemFields.ByName("qty")).Uint()
+			assert.True(t, qty >= 1 && qty < 10)
+		}
+		counts := get(msg, "counts").Map()
+		assert.Equal(t, 2, counts.Len())
+		counts.Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+			assert.NotEmpty(t, k.String())
+			assert.True(t, v.Int() >= 1 && v.Int() < 5)
+			return true
+		})
+
+		card, points := msg.Has(fields.ByName("card")), msg.Has(fields.ByName("points"))
+		assert.True(t, card != points)
+		payments[msg.WhichOneof(md.Oneofs().ByName("payment")).TextName()] = true
+
+		created := get(msg, "created").Message()
+		assert.Equal(t, now.Unix(), created.Get(created.Descriptor().Fields().ByName("seconds")).Int())
+		ttl := get(msg, "ttl").Message()
+		seconds := ttl.Get(ttl.Descriptor().Fields().ByName("seconds")).Int()
+		assert.True(t, seconds >= 0 && seconds < 86400)
+		note := get(msg, "note").Message()
+		assert.Contains(t, []string{"a", "b"}, note.Get(note.Descriptor().Fields().ByName("value")).String())
+		assert.False(t, msg.Has(fields.ByName("parent")))
+		assert.NotEmpty(t, get(msg, "raw").Bytes())
+
+		_, err := proto.Marshal(msg)
+		assert.Nil(t, err)
+	}
+	assert.Len(t, payments, 2)
+
+	// recursive messages end at MaxDepth
+	msg := dynamicpb.NewMessage(md)
+	assert.Nil(t, Mock(m, msg, nil))
+	depth := 0
+	for p := msg.ProtoReflect(); p.Has(fields.ByName("parent")); p = get(p, "parent").Message() {
+		depth++
+	}
+	assert.Equal(t, MaxDepth, depth)
+
+	d := &durationpb.Duration{}
+	assert.Nil(t, Mock(m, d, nil))
+	assert.Nil(t, d.CheckValid())
+}
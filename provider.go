@@ -0,0 +1,336 @@
+package mock
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// TypeProvider generates and validates the values of a type of type tag func,
+// register it by Mocker.RegisterType or Options.Types
+type TypeProvider interface {
+	// Kinds return the supported field types, in the form of typ of ParseTag,
+	// e.g. "string", "int64", "slice"
+	Kinds() []string
+	// Generate return a value of the field kind, the value is converted to the field type
+	Generate(r *rand.Rand, tag Tag, kind reflect.Kind) interface{}
+	// Valid return why data is not a valid value, or "" if it is valid
+	Valid(tag Tag, data interface{}) string
+}
+
+// builtinType is the TypeProvider of the types in TypeList
+type builtinType string
+
+// stringTypes contains the string generators of the types in TypeList
+var stringTypes map[string]func(g generator, tag Tag) string
+
+// intTypes contains the integer generators of the types in TypeList supporting integer fields
+var intTypes map[string]func(g generator, tag Tag) int64
+
+// floatTypes contains the float generators of the types in TypeList supporting float fields
+var floatTypes map[string]func(g generator, tag Tag) float64
+
+// builtinTypes contains the TypeProvider of the types in TypeList
+var builtinTypes = map[string]TypeProvider{}
+
+func init() {
+	for _, name := range TypeList {
+		builtinTypes[name] = builtinType(name)
+	}
+
+	locale := func(fn func(g generator, l *Locale) string) func(g generator, tag Tag) string {
+		return func(g generator, tag Tag) string { return fn(g, tagLocale(tag)) }
+	}
+	pick := func(list func(l *Locale) []string) func(g generator, tag Tag) string {
+		return func(g generator, tag Tag) string { return g.pick(list(tagLocale(tag))) }
+	}
+	text := func(fn func(g generator, tag Tag, n int64) string) func(g generator, tag Tag) string {
+		return func(g generator, tag Tag) string { return fn(g, tag, g.length(tag)) }
+	}
+	blob := func(g generator, tag Tag) string { return string(g.blob(tag)) }
+	id := func(g generator, tag Tag) string { return idString(tag.Type, g.id(tag)) }
+	coordinate := func(g generator, tag Tag) string {
+		return strconv.FormatFloat(g.coordinate(tagLocale(tag), tag.Type), 'f', -1, 64)
+	}
+	email := func(g generator, tag Tag) string {
+		if tag.locale != nil {
+			return g.localeEmail(tag.locale)
+		}
+		return g.eamil()
+	}
+
+	intTypes = map[string]func(g generator, tag Tag) int64{
+		"date":      generator.dateUnix,
+		"port":      func(g generator, tag Tag) int64 { return g.port() },
+		"snowflake": func(g generator, tag Tag) int64 { return g.snowflake() },
+		"amount":    generator.minorAmount,
+	}
+	floatTypes = map[string]func(g generator, tag Tag) float64{
+		"latitude":  func(g generator, tag Tag) float64 { return g.coordinate(tagLocale(tag), tag.Type) },
+		"longitude": func(g generator, tag Tag) float64 { return g.coordinate(tagLocale(tag), tag.Type) },
+		"amount":    generator.amount,
+	}
+
+	stringTypes = map[string]func(g generator, tag Tag) string{
+		"date":  generator.dateString,
+		"email": email,
+		"eamil": email,
+		"phone": func(g generator, tag Tag) string {
+			if tag.locale != nil {
+				return g.localePhone(tag.locale)
+			}
+			return g.phone()
+		},
+		"url": func(g generator, tag Tag) string {
+			if len(tag.Schemes) > 0 {
+				return g.pick(tag.Schemes) + strings.TrimPrefix(g.url(), "http")
+			}
+			return g.url()
+		},
+		"ipv4": func(g generator, tag Tag) string {
+			if tag.Subnet != nil {
+				return g.ip(tag.Subnet).String()
+			}
+			return g.ipv4()
+		},
+		"domain": func(g generator, tag Tag) string { return g.domain() },
+		"word": func(g generator, tag Tag) string {
+			if len(tag.scripts) > 0 {
+				return g.runes(tag, g.length(tag))
+			}
+			lo, hi := tag.intBounds()
+			if tag.locale != nil {
+				return g.localeWord(tag.locale, lo, hi+1)
+			}
+			return g.word(lo, hi+1)
+		},
+		"sentence": func(g generator, tag Tag) string {
+			word := func() string { return g.word(1, 10) }
+			if len(tag.scripts) > 0 {
+				word = func() string { return g.runes(tag, g.int63n(9)+1) }
+			}
+			lo, hi := tag.intBounds()
+			if tag.locale != nil && len(tag.scripts) == 0 {
+				return g.localeSentence(tag.locale, lo, hi+1)
+			}
+			return g.sentence(lo, hi+1, word)
+		},
+
+		"firstname": pick(func(l *Locale) []string { return l.FirstNames }),
+		"lastname":  pick(func(l *Locale) []string { return l.LastNames }),
+		"fullname":  locale(generator.fullname),
+		"username":  locale(generator.username),
+		"street":    locale(generator.street),
+		"city":      pick(func(l *Locale) []string { return l.Cities }),
+		"state":     pick(func(l *Locale) []string { return l.States }),
+		"zip":       locale(generator.zip),
+		"country":   pick(func(l *Locale) []string { return l.Countries }),
+		"latitude":  coordinate,
+		"longitude": coordinate,
+		"company":   locale(generator.company),
+		"jobtitle":  pick(func(l *Locale) []string { return l.JobTitles }),
+
+		"ipv6":     func(g generator, tag Tag) string { return g.ip(g.ipv6Subnet(tag)).String() },
+		"cidr":     func(g generator, tag Tag) string { return g.cidr(tag).String() },
+		"mac":      func(g generator, tag Tag) string { return g.mac().String() },
+		"port":     func(g generator, tag Tag) string { return strconv.FormatInt(g.port(), 10) },
+		"hostname": func(g generator, tag Tag) string { return g.hostname() },
+		"uri":      generator.uri,
+
+		"uuid":      id,
+		"ulid":      id,
+		"objectid":  id,
+		"snowflake": func(g generator, tag Tag) string { return strconv.FormatInt(g.snowflake(), 10) },
+
+		"iban":       func(g generator, tag Tag) string { return g.iban(tag.Format) },
+		"creditcard": func(g generator, tag Tag) string { return g.creditcard(tag.Format) },
+		"currency":   func(g generator, tag Tag) string { return g.pick(currencyCodes()) },
+		"amount": func(g generator, tag Tag) string {
+			return strconv.FormatFloat(g.amount(tag), 'f', Currencies[currency(tag)], 64)
+		},
+		"bic": func(g generator, tag Tag) string { return g.bic() },
+
+		"paragraph": text(generator.paragraph),
+		"lorem":     text(generator.lorem),
+		"markdown":  text(generator.markdown),
+		"html":      text(generator.html),
+
+		"bytes":  blob,
+		"base64": blob,
+		"hex":    blob,
+		"png":    blob,
+		"jpeg":   blob,
+		"pdf":    blob,
+		"csv":    blob,
+		"json":   blob,
+		"file":   blob,
+
+		"hexcolor":  func(g generator, tag Tag) string { return g.hexcolor() },
+		"rgb":       func(g generator, tag Tag) string { return g.rgb() },
+		"hsl":       func(g generator, tag Tag) string { return g.hsl() },
+		"semver":    generator.semver,
+		"mimetype":  func(g generator, tag Tag) string { return g.pick(mimeTypes()) },
+		"filepath":  func(g generator, tag Tag) string { return g.filepath() },
+		"useragent": func(g generator, tag Tag) string { return g.useragent() },
+		"jwt":       generator.jwt,
+		"sha256":    func(g generator, tag Tag) string { return g.sha256() },
+	}
+}
+
+// rangeTypes contains the built-in types which take the range tag func as other than the string length,
+// e.g. the word count of sentence and the size of blobs, range bounds the length of the other types
+var rangeTypes = map[string]bool{
+	"word": true, "sentence": true, "amount": true, "semver": true,
+	"paragraph": true, "lorem": true, "markdown": true, "html": true,
+	"bytes": true, "base64": true, "hex": true, "png": true, "jpeg": true, "pdf": true, "csv": true, "json": true, "file": true,
+}
+
+// Kinds return the field types in typeKinds, default string
+func (b builtinType) Kinds() []string {
+	if kinds, ok := typeKinds[string(b)]; ok {
+		return kinds
+	}
+	return []string{"string"}
+}
+
+// Generate generate the value by a generator of r
+func (b builtinType) Generate(r *rand.Rand, tag Tag, kind reflect.Kind) interface{} {
+	return b.generate(newGenerator(r), tag, kind)
+}
+
+// generate generate the value by g, which keeps the clock, sequence and registered types of the Mocker
+func (b builtinType) generate(g generator, tag Tag, kind reflect.Kind) interface{} {
+	tag.Type = string(b)
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return g.int(tag)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return g.uint(tag)
+	case reflect.Float32, reflect.Float64:
+		return g.float(tag)
+	case reflect.Slice, reflect.Array:
+		if _, ok := idSizes[tag.Type]; ok {
+			return g.id(tag)
+		}
+		return g.blob(tag)
+	}
+	return g.string(tag)
+}
+
+// Valid valid data by validType, and the byte slices by the binary types
+func (b builtinType) Valid(tag Tag, data interface{}) string {
+	tag.Type = string(b)
+	if bs, ok := data.([]byte); ok {
+		if size, ok := idSizes[tag.Type]; ok && len(bs) != size {
+			return tag.Type + " need " + strconv.Itoa(size) + " bytes"
+		}
+		return validBlob(tag, bs)
+	}
+	return validType(tag, reflect.ValueOf(data))
+}
+
+// provide generate the value by p, the built-in types are generated by g
+func (g generator) provide(p TypeProvider, tag Tag, kind reflect.Kind) interface{} {
+	if b, ok := p.(builtinType); ok {
+		return b.generate(g, tag, kind)
+	}
+	return p.Generate(g.rand, tag, kind)
+}
+
+// typeProvider return the TypeProvider of name, the types registered in ctx first
+func (ctx tagContext) typeProvider(name string) (TypeProvider, bool) {
+	if p, ok := ctx.types[name]; ok && p != nil {
+		return p, true
+	}
+	p, ok := builtinTypes[name]
+	return p, ok
+}
+
+func (ctx tagContext) isType(name string) bool {
+	_, ok := ctx.typeProvider(name)
+	return ok
+}
+
+// typeNames return the names of TypeList and the types registered in ctx
+func (ctx tagContext) typeNames() []string {
+	var names []string
+	for name := range ctx.types {
+		if _, ok := builtinTypes[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return append(append([]string(nil), TypeList...), names...)
+}
+
+// RegisterType register a TypeProvider of type tag func, a registered name
+// overrides the type of the same name in TypeList
+func (m *mocker) RegisterType(name string, p TypeProvider) {
+	if m.types == nil {
+		m.types = map[string]TypeProvider{}
+	}
+	m.types[name] = p
+	m.gen.types = m.types
+}
+
+// mockType set v to the value generated by the registered TypeProvider p
+func (m *mocker) mockType(p TypeProvider, t Tag, v reflect.Value) {
+	val := reflect.ValueOf(m.gen.provide(p, t, v.Kind()))
+	switch {
+	case !val.IsValid():
+		return
+	case val.Type().AssignableTo(v.Type()):
+		v.Set(val)
+	case v.Kind() == reflect.String && val.Kind() != reflect.String:
+		v.SetString(fmt.Sprint(val.Interface()))
+	case val.Type().ConvertibleTo(v.Type()):
+		if overflows(val, v.Type()) {
+			m.err = NewConflictError("fieldType", v.Type().String(), "type", t.Type,
+				fmt.Sprintf("generated %v overflows the field type", val.Interface()))
+			return
+		}
+		v.Set(val.Convert(v.Type()))
+	default:
+		m.err = NewConflictError("fieldType", v.Type().String(), "type", t.Type,
+			"generated "+val.Type().String()+" is not convertible to the field type")
+	}
+}
+
+// overflows report whether the number val does not fit in the number type typ,
+// the fractions of floats converted to integers are truncated but not overflows
+func overflows(val reflect.Value, typ reflect.Type) bool {
+	z := reflect.Zero(typ)
+	var signed, unsigned bool
+	switch typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		signed = true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		unsigned = true
+	case reflect.Float32, reflect.Float64:
+	default:
+		return false
+	}
+	switch val.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n := val.Int()
+		return signed && z.OverflowInt(n) || unsigned && (n < 0 || z.OverflowUint(uint64(n)))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n := val.Uint()
+		return signed && (n > math.MaxInt64 || z.OverflowInt(int64(n))) || unsigned && z.OverflowUint(n)
+	case reflect.Float32, reflect.Float64:
+		f := val.Float()
+		switch {
+		case signed:
+			limit := math.Ldexp(1, typ.Bits()-1)
+			return !(f >= -limit && f < limit)
+		case unsigned:
+			return !(f > -1 && f < math.Ldexp(1, typ.Bits()))
+		}
+		return z.OverflowFloat(f)
+	}
+	return false
+}
@@ -0,0 +1,1091 @@
+// Package schema generates and validates JSON values by JSON Schema documents of draft 7 and 2020-12,
+// the values are generated by a mock.Mocker
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"net/url"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/CJH9004/mock"
+)
+
+// MaxDepth is the depth of nested schemas beyond which only the required properties
+// and minItems items are generated, to end the recursive $ref
+const MaxDepth = 8
+
+// Formats map the string formats to the mock tags generating and validating them
+var Formats = map[string]string{
+	"email":     "type(email)",
+	"idn-email": "type(email)",
+	"date-time": "type(date) format(" + time.RFC3339 + ")",
+	"date":      "type(date) format(2006-01-02)",
+	"time":      "type(date) format(15:04:05Z07:00)",
+	"uri":       "type(uri)",
+	"iri":       "type(uri)",
+	"hostname":  "type(hostname)",
+	"ipv4":      "type(ipv4)",
+	"ipv6":      "type(ipv6)",
+	"uuid":      "type(uuid)",
+}
+
+// Access selects the properties of objects by readOnly and writeOnly
+type Access int
+
+const (
+	// AccessAll keeps the readOnly and writeOnly properties
+	AccessAll Access = iota
+	// AccessRead omits the writeOnly properties, e.g. responses
+	AccessRead
+	// AccessWrite omits the readOnly properties, e.g. requests
+	AccessWrite
+)
+
+// Schema is a loaded JSON Schema, $ref of local files are loaded relative to the referring file
+type Schema struct {
+	file     string
+	node     interface{}            // schema referred by Ref, nil is the document of file
+	docs     map[string]interface{} // documents by file path, "" is the parsed document
+	patterns map[string]*regexp.Regexp
+	m        mock.Mocker
+	access   Access
+	examples bool
+}
+
+// Load load the JSON Schema file, m generates the values, a Mocker with random seed is used if m is nil
+func Load(path string, m mock.Mocker) (*Schema, error) {
+	file, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	s := newSchema(file, m)
+	if _, err = s.load(file); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Parse parse the JSON Schema document, $ref of local files are relative to the working directory
+func Parse(data []byte, m mock.Mocker) (*Schema, error) {
+	s := newSchema("", m)
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	s.docs[""] = doc
+	return s, nil
+}
+
+// Document return the Schema of the decoded JSON document doc, e.g. an OpenAPI document,
+// file is the path of doc resolving the $ref of local files
+func Document(doc interface{}, file string, m mock.Mocker) *Schema {
+	s := newSchema(file, m)
+	s.docs[file] = doc
+	return s
+}
+
+// Ref return the Schema referred by ref, e.g. #/components/schemas/User, the Schema
+// shares the documents and the settings of s
+func (s *Schema) Ref(ref string) (*Schema, error) {
+	file, node, err := s.resolve(s.file, ref)
+	if err != nil {
+		return nil, err
+	}
+	r := *s
+	r.file, r.node = file, node
+	return &r, nil
+}
+
+// SetAccess omit the readOnly or writeOnly properties in Generate, and reject them in Validate
+func (s *Schema) SetAccess(access Access) {
+	s.access = access
+}
+
+// SetExamples generate the values by the example and examples keywords if any
+func (s *Schema) SetExamples(examples bool) {
+	s.examples = examples
+}
+
+func newSchema(file string, m mock.Mocker) *Schema {
+	if m == nil {
+		m = mock.New(time.Now().UnixNano(), nil)
+	}
+	return &Schema{file: file, docs: map[string]interface{}{}, patterns: map[string]*regexp.Regexp{}, m: m}
+}
+
+func (s *Schema) load(file string) (interface{}, error) {
+	if doc, ok := s.docs[file]; ok {
+		return doc, nil
+	}
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var doc interface{}
+	if err = json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%s: %v", file, err)
+	}
+	s.docs[file] = doc
+	return doc, nil
+}
+
+// resolve return the file and the schema referred by ref in file, ref is a local file and a JSON pointer,
+// e.g. #/$defs/user, common.json#/definitions/address
+func (s *Schema) resolve(file, ref string) (string, interface{}, error) {
+	path, pointer := ref, ""
+	if i := strings.IndexByte(ref, '#'); i >= 0 {
+		path, pointer = ref[:i], ref[i+1:]
+	}
+	if path != "" {
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(filepath.Dir(file), path)
+		}
+		file = path
+	}
+	node, err := s.load(file)
+	if err != nil {
+		return "", nil, err
+	}
+	if pointer == "" {
+		return file, node, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return "", nil, mock.NewParamError("$ref", "JSON pointer", ref)
+	}
+	for _, token := range strings.Split(pointer[1:], "/") {
+		if t, err := url.PathUnescape(token); err == nil {
+			token = t
+		}
+		token = strings.Replace(strings.Replace(token, "~1", "/", -1), "~0", "~", -1)
+		switch n := node.(type) {
+		case map[string]interface{}:
+			node = n[token]
+		case []interface{}:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(n) {
+				return "", nil, mock.NewParamError("$ref", "existing JSON pointer", ref)
+			}
+			node = n[i]
+		default:
+			node = nil
+		}
+		if node == nil {
+			return "", nil, mock.NewParamError("$ref", "existing JSON pointer", ref)
+		}
+	}
+	return file, node, nil
+}
+
+func (s *Schema) root() interface{} {
+	if s.node != nil {
+		return s.node
+	}
+	return s.docs[s.file]
+}
+
+// Generate return a random value conforming the schema, objects are map[string]interface{},
+// arrays are []interface{}, integers are int64 and numbers are float64
+func (s *Schema) Generate() (interface{}, error) {
+	return s.generate(s.file, s.root(), 0)
+}
+
+// GenerateJSON return the JSON of a random value conforming the schema
+func (s *Schema) GenerateJSON() ([]byte, error) {
+	v, err := s.Generate()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
+
+func (s *Schema) generate(file string, node interface{}, depth int) (interface{}, error) {
+	n, err := object(node)
+	if err != nil || n == nil {
+		return nil, err
+	}
+	if ref, ok := n["$ref"].(string); ok {
+		file, target, err := s.resolve(file, ref)
+		if err != nil {
+			return nil, err
+		}
+		return s.generate(file, target, depth+1)
+	}
+	if s.examples {
+		if v, ok := n["example"]; ok {
+			return v, nil
+		}
+		if examples := list(n["examples"]); len(examples) > 0 {
+			return examples[s.intn(len(examples))], nil
+		}
+	}
+	if n["nullable"] == true && s.intn(4) == 0 {
+		return nil, nil
+	}
+	if v, ok := n["const"]; ok {
+		return v, nil
+	}
+	if enum, ok := n["enum"].([]interface{}); ok && len(enum) > 0 {
+		return enum[s.intn(len(enum))], nil
+	}
+	if all, ok := n["allOf"].([]interface{}); ok {
+		if n, err = s.merge(file, n, all); err != nil {
+			return nil, err
+		}
+	}
+	for _, key := range []string{"oneOf", "anyOf"} {
+		if list, ok := n[key].([]interface{}); ok && len(list) > 0 {
+			return s.generateOf(file, n, list, depth)
+		}
+	}
+
+	switch typ := s.typeOf(n); typ {
+	case "object":
+		return s.generateObject(file, n, depth)
+	case "array":
+		return s.generateArray(file, n, depth)
+	case "string":
+		return s.generateString(n)
+	case "integer":
+		return s.generateInteger(n)
+	case "number":
+		return s.generateNumber(n)
+	case "boolean":
+		var b bool
+		err = s.m.Mock("", &b)
+		return b, err
+	case "null":
+		return nil, nil
+	default:
+		return nil, mock.NewParamError("type", "object/array/string/integer/number/boolean/null", typ)
+	}
+}
+
+// object return the schema object of node, a nil object for the true schema
+func object(node interface{}) (map[string]interface{}, error) {
+	switch n := node.(type) {
+	case map[string]interface{}:
+		return n, nil
+	case bool:
+		if !n {
+			return nil, mock.NewParamError("schema", "satisfiable schema", false)
+		}
+		return nil, nil
+	}
+	return nil, mock.NewParamError("schema", "object or boolean", node)
+}
+
+// merge return n with the properties, required and other keywords of the allOf schemas
+func (s *Schema) merge(file string, n map[string]interface{}, all []interface{}) (map[string]interface{}, error) {
+	merged := map[string]interface{}{}
+	for k, v := range n {
+		if k != "allOf" {
+			merged[k] = v
+		}
+	}
+	for _, node := range all {
+		f, sub, err := file, node, error(nil)
+		if o, ok := node.(map[string]interface{}); ok {
+			if ref, ok := o["$ref"].(string); ok {
+				if f, sub, err = s.resolve(file, ref); err != nil {
+					return nil, err
+				}
+			}
+		}
+		o, err := object(sub)
+		if err != nil {
+			return nil, err
+		}
+		if inner, ok := o["allOf"].([]interface{}); ok {
+			if o, err = s.merge(f, o, inner); err != nil {
+				return nil, err
+			}
+		}
+		for k, v := range o {
+			switch k {
+			case "properties":
+				props, _ := merged[k].(map[string]interface{})
+				union := map[string]interface{}{}
+				for name, p := range props {
+					union[name] = p
+				}
+				add, ok := v.(map[string]interface{})
+				if !ok {
+					return nil, mock.NewParamError(k, "object", v)
+				}
+				for name, p := range add {
+					union[name] = p
+				}
+				merged[k] = union
+			case "required":
+				req, _ := merged[k].([]interface{})
+				add, ok := v.([]interface{})
+				if !ok {
+					return nil, mock.NewParamError(k, "array", v)
+				}
+				merged[k] = append(append([]interface{}{}, req...), add...)
+			default:
+				if _, ok := merged[k]; !ok {
+					merged[k] = v
+				}
+			}
+		}
+	}
+	return merged, nil
+}
+
+// generateOf generate a value of a random branch of oneOf or anyOf, and retry when
+// the value does not satisfy n, e.g. a value of oneOf matches another branch,
+// the discriminator property of OpenAPI is set to the name of the branch
+func (s *Schema) generateOf(file string, n map[string]interface{}, list []interface{}, depth int) (interface{}, error) {
+	var v interface{}
+	var err error
+	for i := 0; i < mock.UniqueRetry; i++ {
+		branch := list[s.intn(len(list))]
+		if v, err = s.generate(file, branch, depth+1); err != nil {
+			return nil, err
+		}
+		if obj, ok := v.(map[string]interface{}); ok {
+			if name, value, ok := discriminator(n, branch); ok {
+				obj[name] = value
+			}
+		}
+		if err = s.validate(file, n, "", normalize(v)); err == nil {
+			return v, nil
+		}
+	}
+	return nil, err
+}
+
+// typeOf return a random type of n, or the type inferred by the keywords
+func (s *Schema) typeOf(n map[string]interface{}) string {
+	switch t := n["type"].(type) {
+	case string:
+		return t
+	case []interface{}:
+		if len(t) > 0 {
+			typ, _ := t[s.intn(len(t))].(string)
+			return typ
+		}
+	}
+	for _, keys := range [][]string{
+		{"object", "properties", "required", "additionalProperties", "minProperties"},
+		{"array", "items", "prefixItems", "minItems", "maxItems"},
+		{"number", "minimum", "maximum", "exclusiveMinimum", "exclusiveMaximum", "multipleOf"},
+	} {
+		for _, k := range keys[1:] {
+			if _, ok := n[k]; ok {
+				return keys[0]
+			}
+		}
+	}
+	return "string"
+}
+
+func (s *Schema) generateObject(file string, n map[string]interface{}, depth int) (interface{}, error) {
+	props, _ := n["properties"].(map[string]interface{})
+	required := map[string]bool{}
+	for _, r := range list(n["required"]) {
+		if name, ok := r.(string); ok {
+			required[name] = true
+		}
+	}
+	obj := map[string]interface{}{}
+	for _, name := range sortedKeys(props) {
+		if s.omitted(file, props[name]) != "" {
+			delete(required, name)
+			continue
+		}
+		if !required[name] && (depth >= MaxDepth || s.intn(2) == 0) {
+			continue
+		}
+		v, err := s.generate(file, props[name], depth+1)
+		if err != nil {
+			return nil, err
+		}
+		obj[name] = v
+	}
+	// the required properties without schema and minProperties are generated by additionalProperties
+	additional, ok := n["additionalProperties"]
+	if !ok || additional == true || additional == false {
+		additional = map[string]interface{}{"type": "string"}
+	}
+	for _, name := range sortedKeys(required) {
+		if _, ok := obj[name]; ok {
+			continue
+		}
+		v, err := s.generate(file, additional, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		obj[name] = v
+	}
+	min, _ := number(n["minProperties"])
+	for i := 0; len(obj) < int(min) && n["additionalProperties"] != false && i < mock.UniqueRetry; i++ {
+		var name string
+		if err := s.m.Mock("type(word)", &name); err != nil {
+			return nil, err
+		}
+		if _, ok := obj[name]; ok {
+			continue
+		}
+		v, err := s.generate(file, additional, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		obj[name] = v
+	}
+	return obj, nil
+}
+
+func (s *Schema) generateArray(file string, n map[string]interface{}, depth int) (interface{}, error) {
+	prefix := list(n["prefixItems"])
+	items, ok := n["items"]
+	if tuple, isTuple := items.([]interface{}); isTuple {
+		prefix, items, ok = tuple, n["additionalItems"], n["additionalItems"] != nil
+	}
+	if !ok || items == true {
+		items = map[string]interface{}{"type": "string"}
+	}
+	lo, hasLo := number(n["minItems"])
+	hi, hasHi := number(n["maxItems"])
+	switch {
+	case depth >= MaxDepth:
+		hi = lo
+	case !hasLo && !hasHi:
+		lo, hi = 1, 5
+	case !hasHi:
+		hi = lo + 4
+	case !hasLo && hi > 0:
+		lo = 1
+	}
+	if items == false && hi > float64(len(prefix)) {
+		hi = float64(len(prefix))
+	}
+	length := int(lo)
+	if hi > lo {
+		length += s.intn(int(hi-lo) + 1)
+	}
+	unique, _ := n["uniqueItems"].(bool)
+	arr := make([]interface{}, 0, length)
+	seen := map[string]bool{}
+	for i := 0; i < length; i++ {
+		node := items
+		if i < len(prefix) {
+			node = prefix[i]
+		}
+		for retry := 0; ; retry++ {
+			v, err := s.generate(file, node, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			key, _ := json.Marshal(v)
+			if !unique || !seen[string(key)] {
+				seen[string(key)] = true
+				arr = append(arr, v)
+				break
+			}
+			if retry >= mock.UniqueRetry {
+				return nil, mock.NewInvalidError("", v, fmt.Sprintf("no unique item in %d retries", mock.UniqueRetry))
+			}
+		}
+	}
+	return arr, nil
+}
+
+// generateString generate a string by format, pattern or the length bounds, the strings of
+// format and pattern are regenerated until their lengths are in the bounds
+func (s *Schema) generateString(n map[string]interface{}) (interface{}, error) {
+	format, _ := n["format"].(string)
+	pattern, _ := n["pattern"].(string)
+	lo, hasLo := number(n["minLength"])
+	hi, hasHi := number(n["maxLength"])
+	var name, tags string
+	switch {
+	case Formats[format] != "":
+		name, tags = "format", Formats[format]
+	case pattern != "":
+		name, tags = "pattern", "pattern("+pattern+")"
+	default:
+		if !hasLo && hi > 0 {
+			lo = 1
+		}
+		if !hasHi {
+			hi = math.Max(lo, 1) + 9
+		}
+		tags = fmt.Sprintf("range(%d, %d])", int64(lo), int64(hi))
+	}
+	var str string
+	for i := 0; i < mock.UniqueRetry; i++ {
+		if err := s.m.Mock(tags, &str); err != nil {
+			return nil, err
+		}
+		length := float64(utf8.RuneCountInString(str))
+		if (!hasLo || length >= lo) && (!hasHi || length <= hi) {
+			return str, nil
+		}
+	}
+	return nil, mock.NewConflictError(name, n[name], "length", fmt.Sprintf("[%v, %v]", n["minLength"], n["maxLength"]),
+		fmt.Sprintf("no string of the %s in the length bounds in %d retries", name, mock.UniqueRetry))
+}
+
+// bounds return the bounds of the number schema n and whether they are exclusive, the bounds
+// default to 0 and 100, and a single bound is extended by 100
+func bounds(n map[string]interface{}) (lo, hi float64, minExclusive, maxExclusive bool) {
+	lo, hasLo := number(n["minimum"])
+	hi, hasHi := number(n["maximum"])
+	minExclusive = hasLo && n["exclusiveMinimum"] == true
+	maxExclusive = hasHi && n["exclusiveMaximum"] == true
+	if v, ok := number(n["exclusiveMinimum"]); ok && (!hasLo || v >= lo) {
+		lo, hasLo, minExclusive = v, true, true
+	}
+	if v, ok := number(n["exclusiveMaximum"]); ok && (!hasHi || v <= hi) {
+		hi, hasHi, maxExclusive = v, true, true
+	}
+	switch {
+	case !hasLo && !hasHi:
+		lo, hi = 0, 100
+	case !hasLo:
+		lo = hi - 100
+	case !hasHi:
+		hi = lo + 100
+	}
+	return lo, hi, minExclusive, maxExclusive
+}
+
+func (s *Schema) generateInteger(n map[string]interface{}) (interface{}, error) {
+	step, ok := number(n["multipleOf"])
+	if !ok || step <= 0 {
+		step = 1
+	}
+	v, err := s.multiple(n, step)
+	if err != nil || v != math.Trunc(v) {
+		return v, err
+	}
+	return int64(v), nil
+}
+
+func (s *Schema) generateNumber(n map[string]interface{}) (interface{}, error) {
+	if step, ok := number(n["multipleOf"]); ok && step > 0 {
+		return s.multiple(n, step)
+	}
+	lo, hi, minExclusive, maxExclusive := bounds(n)
+	tags := "range(" + formatFloat(lo) + ", " + formatFloat(hi) + "])"
+	if minExclusive {
+		tags = "range(]" + tags[len("range("):]
+	}
+	if maxExclusive {
+		tags = strings.TrimSuffix(tags, "])") + ")"
+	}
+	var f float64
+	err := s.m.Mock(tags, &f)
+	return f, err
+}
+
+// multiple return a random multiple of step in the bounds of n
+func (s *Schema) multiple(n map[string]interface{}, step float64) (float64, error) {
+	lo, hi, minExclusive, maxExclusive := bounds(n)
+	klo, khi := math.Ceil(lo/step), math.Floor(hi/step)
+	if minExclusive && klo*step <= lo {
+		klo++
+	}
+	if maxExclusive && khi*step >= hi {
+		khi--
+	}
+	k, err := s.int(klo, khi)
+	return float64(k) * step, err
+}
+
+// int return a random integer in [lo, hi]
+func (s *Schema) int(lo, hi float64) (int64, error) {
+	if lo > hi {
+		return 0, mock.NewParamError("schema", "satisfiable bounds", fmt.Sprintf("[%v, %v]", lo, hi))
+	}
+	var k int64
+	err := s.m.Mock(fmt.Sprintf("range(%d, %d])", int64(lo), int64(hi)), &k)
+	return k, err
+}
+
+// intn return a random int in [0, n)
+func (s *Schema) intn(n int) int {
+	k, _ := s.int(0, float64(n-1))
+	return int(k)
+}
+
+// Validate valid v by the schema, v is a JSON value or a value marshaled by encoding/json,
+// the error is a mock.InvalidError with the path of the invalid value
+func (s *Schema) Validate(v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return s.ValidateJSON(data)
+}
+
+// ValidateJSON valid the JSON document by the schema
+func (s *Schema) ValidateJSON(data []byte) error {
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	var v interface{}
+	if err := d.Decode(&v); err != nil {
+		return err
+	}
+	return s.validate(s.file, s.root(), "", v)
+}
+
+// normalize convert the generated value to the decoded JSON value
+func normalize(v interface{}) interface{} {
+	data, _ := json.Marshal(v)
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	var n interface{}
+	d.Decode(&n)
+	return n
+}
+
+func (s *Schema) validate(file string, node interface{}, path string, v interface{}) error {
+	if b, ok := node.(bool); ok {
+		if !b {
+			return mock.NewInvalidError(path, v, "not allowed by false schema")
+		}
+		return nil
+	}
+	n, ok := node.(map[string]interface{})
+	if !ok {
+		return mock.NewParamError("schema", "object or boolean", node)
+	}
+	if v == nil && n["nullable"] == true {
+		return nil
+	}
+	if ref, ok := n["$ref"].(string); ok {
+		f, target, err := s.resolve(file, ref)
+		if err != nil {
+			return err
+		}
+		if err = s.validate(f, target, path, v); err != nil {
+			return err
+		}
+	}
+	if err := s.validateType(n, path, v); err != nil {
+		return err
+	}
+	if c, ok := n["const"]; ok && !equal(c, v) {
+		return mock.NewInvalidError(path, v, fmt.Sprintf("not const %v", c))
+	}
+	if enum, ok := n["enum"].([]interface{}); ok {
+		found := false
+		for _, e := range enum {
+			found = found || equal(e, v)
+		}
+		if !found {
+			return mock.NewInvalidError(path, v, fmt.Sprintf("not in enum%v", enum))
+		}
+	}
+	if err := s.validateOf(file, n, path, v); err != nil {
+		return err
+	}
+
+	switch val := v.(type) {
+	case string:
+		return s.validateString(n, path, val)
+	case json.Number:
+		f, _ := val.Float64()
+		return validateNumber(n, path, f)
+	case []interface{}:
+		return s.validateArray(file, n, path, val)
+	case map[string]interface{}:
+		return s.validateObject(file, n, path, val)
+	}
+	return nil
+}
+
+func (s *Schema) validateType(n map[string]interface{}, path string, v interface{}) error {
+	var types []interface{}
+	switch t := n["type"].(type) {
+	case string:
+		types = []interface{}{t}
+	case []interface{}:
+		types = t
+	default:
+		return nil
+	}
+	actual := jsonType(v)
+	for _, t := range types {
+		if t == actual || t == "number" && actual == "integer" {
+			return nil
+		}
+	}
+	return mock.NewInvalidError(path, v, fmt.Sprintf("%s is not type %v", actual, n["type"]))
+}
+
+func (s *Schema) validateOf(file string, n map[string]interface{}, path string, v interface{}) error {
+	for _, sub := range list(n["allOf"]) {
+		if err := s.validate(file, sub, path, v); err != nil {
+			return err
+		}
+	}
+	if any := list(n["anyOf"]); len(any) > 0 {
+		var err error
+		for _, sub := range any {
+			if err = s.validate(file, sub, path, v); err == nil {
+				break
+			}
+		}
+		if err != nil {
+			return mock.NewInvalidError(path, v, "not match anyOf")
+		}
+	}
+	if branch, ok, err := s.discriminated(file, n, path, v); ok || err != nil {
+		if err == nil {
+			err = s.validate(file, branch, path, v)
+		}
+		return err
+	}
+	if one := list(n["oneOf"]); len(one) > 0 {
+		matched := 0
+		for _, sub := range one {
+			if s.validate(file, sub, path, v) == nil {
+				matched++
+			}
+		}
+		if matched != 1 {
+			return mock.NewInvalidError(path, v, fmt.Sprintf("match %d schemas of oneOf", matched))
+		}
+	}
+	if not, ok := n["not"]; ok && s.validate(file, not, path, v) == nil {
+		return mock.NewInvalidError(path, v, "match not")
+	}
+	return nil
+}
+
+func (s *Schema) validateString(n map[string]interface{}, path, v string) error {
+	length := float64(utf8.RuneCountInString(v))
+	if min, ok := number(n["minLength"]); ok && length < min {
+		return mock.NewInvalidError(path, v, fmt.Sprintf("length < minLength %v", min))
+	}
+	if max, ok := number(n["maxLength"]); ok && length > max {
+		return mock.NewInvalidError(path, v, fmt.Sprintf("length > maxLength %v", max))
+	}
+	if pattern, ok := n["pattern"].(string); ok {
+		re, err := s.compile(pattern)
+		if err != nil {
+			return err
+		}
+		if !re.MatchString(v) {
+			return mock.NewInvalidError(path, v, fmt.Sprintf("not match pattern %s", pattern))
+		}
+	}
+	if format, ok := n["format"].(string); ok && Formats[format] != "" {
+		if ok, _ := s.m.Valid(Formats[format], v); !ok {
+			return mock.NewInvalidError(path, v, "not format "+format)
+		}
+	}
+	return nil
+}
+
+func (s *Schema) compile(pattern string) (*regexp.Regexp, error) {
+	if re, ok := s.patterns[pattern]; ok {
+		return re, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, mock.NewParamError("pattern", "regular expression", pattern)
+	}
+	s.patterns[pattern] = re
+	return re, nil
+}
+
+func validateNumber(n map[string]interface{}, path string, v float64) error {
+	if min, ok := number(n["minimum"]); ok && (v < min || v == min && n["exclusiveMinimum"] == true) {
+		return mock.NewInvalidError(path, v, fmt.Sprintf("less than minimum %v", min))
+	}
+	if max, ok := number(n["maximum"]); ok && (v > max || v == max && n["exclusiveMaximum"] == true) {
+		return mock.NewInvalidError(path, v, fmt.Sprintf("greater than maximum %v", max))
+	}
+	if min, ok := number(n["exclusiveMinimum"]); ok && v <= min {
+		return mock.NewInvalidError(path, v, fmt.Sprintf("not greater than exclusiveMinimum %v", min))
+	}
+	if max, ok := number(n["exclusiveMaximum"]); ok && v >= max {
+		return mock.NewInvalidError(path, v, fmt.Sprintf("not less than exclusiveMaximum %v", max))
+	}
+	if step, ok := number(n["multipleOf"]); ok && step > 0 {
+		q := v / step
+		if math.Abs(q-math.Round(q)) > 1e-9*math.Max(1, math.Abs(q)) {
+			return mock.NewInvalidError(path, v, fmt.Sprintf("not multipleOf %v", step))
+		}
+	}
+	return nil
+}
+
+func (s *Schema) validateArray(file string, n map[string]interface{}, path string, v []interface{}) error {
+	length := float64(len(v))
+	if min, ok := number(n["minItems"]); ok && length < min {
+		return mock.NewInvalidError(path, v, fmt.Sprintf("items < minItems %v", min))
+	}
+	if max, ok := number(n["maxItems"]); ok && length > max {
+		return mock.NewInvalidError(path, v, fmt.Sprintf("items > maxItems %v", max))
+	}
+	if unique, _ := n["uniqueItems"].(bool); unique {
+		for i := range v {
+			for j := 0; j < i; j++ {
+				if equal(v[i], v[j]) {
+					return mock.NewInvalidError(fmt.Sprintf("%s[%d]", path, i), v[i], fmt.Sprintf("duplicate of item %d", j))
+				}
+			}
+		}
+	}
+	prefix := list(n["prefixItems"])
+	items, ok := n["items"]
+	if tuple, isTuple := items.([]interface{}); isTuple {
+		prefix, items, ok = tuple, n["additionalItems"], n["additionalItems"] != nil
+	}
+	for i, item := range v {
+		node := items
+		if i < len(prefix) {
+			node = prefix[i]
+		} else if !ok {
+			continue
+		}
+		if err := s.validate(file, node, fmt.Sprintf("%s[%d]", path, i), item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Schema) validateObject(file string, n map[string]interface{}, path string, v map[string]interface{}) error {
+	props, _ := n["properties"].(map[string]interface{})
+	for _, name := range sortedKeys(props) {
+		if _, ok := v[name]; ok {
+			if key := s.omitted(file, props[name]); key != "" {
+				return mock.NewInvalidError(joinPath(path, name), v[name], key)
+			}
+		}
+	}
+	for _, r := range list(n["required"]) {
+		if name, ok := r.(string); ok {
+			if _, ok := v[name]; !ok && (props[name] == nil || s.omitted(file, props[name]) == "") {
+				return mock.NewInvalidError(joinPath(path, name), nil, "required")
+			}
+		}
+	}
+	count := float64(len(v))
+	if min, ok := number(n["minProperties"]); ok && count < min {
+		return mock.NewInvalidError(path, v, fmt.Sprintf("properties < minProperties %v", min))
+	}
+	if max, ok := number(n["maxProperties"]); ok && count > max {
+		return mock.NewInvalidError(path, v, fmt.Sprintf("properties > maxProperties %v", max))
+	}
+	patterns, _ := n["patternProperties"].(map[string]interface{})
+	additional, hasAdditional := n["additionalProperties"]
+	for _, name := range sortedKeys(v) {
+		p := joinPath(path, name)
+		matched := false
+		if node, ok := props[name]; ok {
+			matched = true
+			if err := s.validate(file, node, p, v[name]); err != nil {
+				return err
+			}
+		}
+		for _, pattern := range sortedKeys(patterns) {
+			re, err := s.compile(pattern)
+			if err != nil {
+				return err
+			}
+			if re.MatchString(name) {
+				matched = true
+				if err := s.validate(file, patterns[pattern], p, v[name]); err != nil {
+					return err
+				}
+			}
+		}
+		if !matched && hasAdditional {
+			if err := s.validate(file, additional, p, v[name]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// omitted return readOnly or writeOnly if the property schema node is omitted by the access of s
+func (s *Schema) omitted(file string, node interface{}) string {
+	key := ""
+	switch s.access {
+	case AccessRead:
+		key = "writeOnly"
+	case AccessWrite:
+		key = "readOnly"
+	default:
+		return ""
+	}
+	for i := 0; i < MaxDepth; i++ {
+		n, ok := node.(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		if n[key] == true {
+			return key
+		}
+		ref, ok := n["$ref"].(string)
+		if !ok {
+			return ""
+		}
+		var err error
+		if file, node, err = s.resolve(file, ref); err != nil {
+			return ""
+		}
+	}
+	return ""
+}
+
+// discriminator return the property name and the value of the OpenAPI discriminator of n
+// for the branch, the value is the key of mapping or the schema name of the $ref of branch
+func discriminator(n map[string]interface{}, branch interface{}) (string, string, bool) {
+	d, _ := n["discriminator"].(map[string]interface{})
+	name, _ := d["propertyName"].(string)
+	b, _ := branch.(map[string]interface{})
+	ref, _ := b["$ref"].(string)
+	if name == "" || ref == "" {
+		return "", "", false
+	}
+	mapping, _ := d["mapping"].(map[string]interface{})
+	for _, value := range sortedKeys(mapping) {
+		// the mapping value is a $ref or a schema name
+		if m, _ := mapping[value].(string); m == ref || strings.HasSuffix(ref, "/"+m) {
+			return name, value, true
+		}
+	}
+	return name, ref[strings.LastIndexByte(ref, '/')+1:], true
+}
+
+// discriminated return the branch of oneOf or anyOf selected by the discriminator property of v,
+// and report whether n has a discriminator
+func (s *Schema) discriminated(file string, n map[string]interface{}, path string, v interface{}) (interface{}, bool, error) {
+	obj, ok := v.(map[string]interface{})
+	branches := append(append([]interface{}{}, list(n["oneOf"])...), list(n["anyOf"])...)
+	if !ok || len(branches) == 0 {
+		return nil, false, nil
+	}
+	for _, branch := range branches {
+		name, value, ok := discriminator(n, branch)
+		if !ok {
+			return nil, false, nil
+		}
+		if obj[name] == value {
+			return branch, true, nil
+		}
+	}
+	name, _, _ := discriminator(n, branches[0])
+	return nil, true, mock.NewInvalidError(joinPath(path, name), obj[name], "unknown discriminator")
+}
+
+func jsonType(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case json.Number:
+		if f, err := v.Float64(); err == nil && f == math.Trunc(f) {
+			return "integer"
+		}
+		return "number"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return reflect.TypeOf(v).String()
+}
+
+// equal report whether the JSON values are equal, numbers are compared by value
+func equal(a, b interface{}) bool {
+	fa, okA := number(a)
+	fb, okB := number(b)
+	if okA || okB {
+		return okA && okB && fa == fb
+	}
+	switch a := a.(type) {
+	case []interface{}:
+		b, ok := b.([]interface{})
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if !equal(a[i], b[i]) {
+				return false
+			}
+		}
+		return true
+	case map[string]interface{}:
+		b, ok := b.(map[string]interface{})
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for k, v := range a {
+			if w, ok := b[k]; !ok || !equal(v, w) {
+				return false
+			}
+		}
+		return true
+	}
+	return a == b
+}
+
+// number return the float64 of the JSON number v
+func number(v interface{}) (float64, bool) {
+	switch v := v.(type) {
+	case float64:
+		return v, true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	case int64:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	}
+	return 0, false
+}
+
+func list(v interface{}) []interface{} {
+	l, _ := v.([]interface{})
+	return l
+}
+
+func sortedKeys(m interface{}) []string {
+	rv := reflect.ValueOf(m)
+	if rv.Kind() != reflect.Map {
+		return nil
+	}
+	keys := make([]string, 0, rv.Len())
+	for _, k := range rv.MapKeys() {
+		keys = append(keys, k.String())
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
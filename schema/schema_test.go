@@ -0,0 +1,161 @@
+package schema
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/CJH9004/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+const userSchema = `{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"type": "object",
+	"required": ["id", "name", "email", "created", "role", "tags", "address", "contact"],
+	"properties": {
+		"id": {"type": "integer", "minimum": 1, "maximum": 1000, "multipleOf": 5},
+		"name": {"type": "string", "minLength": 3, "maxLength": 8},
+		"code": {"type": "string", "pattern": "^[A-Z]{3}-[0-9]{4}$"},
+		"email": {"type": "string", "format": "email"},
+		"site": {"type": "string", "format": "uri"},
+		"ip": {"type": "string", "format": "ipv4"},
+		"created": {"type": "string", "format": "date-time"},
+		"score": {"type": "number", "exclusiveMinimum": 0, "exclusiveMaximum": 1},
+		"role": {"enum": ["admin", "user", null]},
+		"active": {"type": "boolean"},
+		"tags": {"type": "array", "items": {"type": "string", "maxLength": 5}, "minItems": 2, "maxItems": 4, "uniqueItems": true},
+		"address": {"$ref": "common.json#/$defs/address"},
+		"contact": {"oneOf": [
+			{"type": "object", "required": ["phone"], "properties": {"phone": {"type": "string", "pattern": "^[0-9]{11}$"}}, "additionalProperties": false},
+			{"type": "object", "required": ["wechat"], "properties": {"wechat": {"type": "string"}}, "additionalProperties": false}
+		]},
+		"friends": {"type": "array", "items": {"$ref": "#"}, "maxItems": 2}
+	},
+	"additionalProperties": false
+}`
+
+const commonSchema = `{
+	"$defs": {
+		"address": {
+			"allOf": [
+				{"type": "object", "required": ["city"], "properties": {"city": {"type": "string"}}},
+				{"required": ["zip"], "properties": {"zip": {"type": "string", "pattern": "^[0-9]{6}$"}}}
+			]
+		}
+	}
+}`
+
+func TestSchema(t *testing.T) {
+	dir, err := ioutil.TempDir("", "schema")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "user.json"), []byte(userSchema), 0644))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "common.json"), []byte(commonSchema), 0644))
+
+	s, err := Load(filepath.Join(dir, "user.json"), mock.New(time.Now().UnixNano(), nil))
+	assert.Nil(t, err)
+	for i := 0; i < 50; i++ {
+		v, err := s.Generate()
+		assert.Nil(t, err)
+		assert.Nil(t, s.Validate(v))
+		user := v.(map[string]interface{})
+		id := user["id"].(int64)
+		assert.True(t, id >= 5 && id <= 1000 && id%5 == 0, id)
+		assert.Contains(t, []interface{}{"admin", "user", nil}, user["role"])
+		_, err = time.Parse(time.RFC3339, user["created"].(string))
+		assert.Nil(t, err)
+		address := user["address"].(map[string]interface{})
+		assert.Regexp(t, `^[0-9]{6}$`, address["zip"])
+		assert.NotNil(t, address["city"])
+		assert.Len(t, user["contact"], 1)
+
+		data, err := s.GenerateJSON()
+		assert.Nil(t, err)
+		assert.Nil(t, s.ValidateJSON(data))
+	}
+
+	valid := map[string]interface{}{
+		"id": 10, "name": "alice", "email": "a@b.cn", "created": "2020-01-02T03:04:05Z", "role": "user",
+		"tags": []string{"a", "b"}, "address": map[string]string{"city": "x", "zip": "100000"},
+		"contact": map[string]string{"phone": "13800000000"},
+	}
+	assert.Nil(t, s.Validate(valid))
+	invalid := []struct {
+		path  string
+		key   string
+		value interface{}
+	}{
+		{"id", "id", 12},
+		{"id", "id", 1.5},
+		{"name", "name", "ab"},
+		{"email", "email", "ab"},
+		{"created", "created", "2020-01-02"},
+		{"score", "score", 1},
+		{"role", "role", "root"},
+		{"tags[1]", "tags", []string{"a", "a"}},
+		{"tags[0]", "tags", []string{"abcdef", "a"}},
+		{"address.zip", "address", map[string]string{"city": "x", "zip": "1"}},
+		{"address.city", "address", map[string]string{"zip": "100000"}},
+		{"contact", "contact", map[string]string{"phone": "138", "wechat": "x"}},
+		{"friends[0].id", "friends", []interface{}{map[string]interface{}{}}},
+		{"extra", "extra", 1},
+	}
+	for _, c := range invalid {
+		v := map[string]interface{}{}
+		for k, val := range valid {
+			v[k] = val
+		}
+		v[c.key] = c.value
+		err := s.Validate(v)
+		if assert.IsType(t, mock.InvalidError{}, err, c.path) {
+			assert.Equal(t, c.path, err.(mock.InvalidError).Path)
+		}
+	}
+
+	s, err = Parse([]byte(`{"type": "array", "prefixItems": [{"const": 1}, {"type": "number", "multipleOf": 0.5, "minimum": 1, "maximum": 2}], "items": false}`), nil)
+	assert.Nil(t, err)
+	v, err := s.Generate()
+	assert.Nil(t, err)
+	data, _ := json.Marshal(v)
+	assert.Regexp(t, `^\[1(,(1|1\.5|2))?\]$`, string(data))
+	assert.NotNil(t, s.ValidateJSON([]byte(`[1, 1.5, 2]`)))
+	assert.NotNil(t, s.ValidateJSON([]byte(`[1, 1.2]`)))
+
+	s, err = Parse([]byte(`{"$ref": "#/$defs/missing"}`), nil)
+	assert.Nil(t, err)
+	_, err = s.Generate()
+	assert.IsType(t, mock.ParamError{}, err)
+
+	// the length bounds apply to format and pattern
+	s, err = Parse([]byte(`{"type": "array", "minItems": 2, "items": false, "prefixItems": [
+		{"type": "string", "format": "email", "maxLength": 12},
+		{"type": "string", "pattern": "^[a-z]{1,10}$", "minLength": 8}]}`), nil)
+	assert.Nil(t, err)
+	for i := 0; i < 20; i++ {
+		v, err := s.Generate()
+		assert.Nil(t, err)
+		assert.Nil(t, s.Validate(v))
+		if assert.Len(t, v, 2) {
+			assert.True(t, len(v.([]interface{})[0].(string)) <= 12, v)
+			assert.True(t, len(v.([]interface{})[1].(string)) >= 8, v)
+		}
+	}
+	s, err = Parse([]byte(`{"type": "string", "format": "email", "maxLength": 3}`), nil)
+	assert.Nil(t, err)
+	_, err = s.Generate()
+	assert.IsType(t, mock.ConflictError{}, err)
+
+	// malformed allOf
+	s, err = Parse([]byte(`{"allOf": [{"properties": []}]}`), nil)
+	assert.Nil(t, err)
+	_, err = s.Generate()
+	assert.IsType(t, mock.ParamError{}, err)
+	s, err = Parse([]byte(`{"allOf": [{"required": "id"}]}`), nil)
+	assert.Nil(t, err)
+	_, err = s.Generate()
+	assert.IsType(t, mock.ParamError{}, err)
+}
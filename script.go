@@ -0,0 +1,127 @@
+package mock
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Emoji is the range table of the emoji used by script(Emoji)
+var Emoji = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x2600, Hi: 0x26ff, Stride: 1},
+		{Lo: 0x2700, Hi: 0x27bf, Stride: 1},
+	},
+	R32: []unicode.Range32{
+		{Lo: 0x1f300, Hi: 0x1f5ff, Stride: 1},
+		{Lo: 0x1f600, Hi: 0x1f64f, Stride: 1},
+		{Lo: 0x1f680, Hi: 0x1f6ff, Stride: 1},
+		{Lo: 0x1f900, Hi: 0x1f9ff, Stride: 1},
+	},
+}
+
+// EdgeRunes are the runes mixed in by script(..., edge): joiners, direction marks,
+// variation selector, byte order mark, replacement char and surrogate-adjacent code points
+var EdgeRunes = []rune{'\u200d', '\u200c', '\u200f', '\u200e', '\ufe0f', '\ufeff', '\ufffd', '\ud7ff', '\ue000', '\U0010fffd'}
+
+// scriptTable return the range table of a script name, e.g. Han, Latin, Arabic, Emoji
+func scriptTable(name string) *unicode.RangeTable {
+	if name == "Emoji" {
+		return Emoji
+	}
+	return unicode.Scripts[name]
+}
+
+// parseScript parse the script tag func, e.g. script(Han|Latin, marks, edge)
+func parseScript(t *Tag, param string) error {
+	opts := strings.Split(param, ",")
+	for _, name := range strings.Split(opts[0], "|") {
+		name = strings.TrimSpace(name)
+		table := scriptTable(name)
+		if table == nil {
+			return NewParamError("script", "unicode script name or Emoji", name)
+		}
+		t.Scripts = append(t.Scripts, name)
+		t.scripts = append(t.scripts, table)
+	}
+	for _, opt := range opts[1:] {
+		switch strings.TrimSpace(opt) {
+		case "marks":
+			t.Marks = true
+		case "edge":
+			t.Edge = true
+		default:
+			return NewParamError("script", "marks or edge", opt)
+		}
+	}
+	return nil
+}
+
+// runes return a string of n runes in the scripts of tag
+func (g generator) runes(tag Tag, n int64) string {
+	b := make([]rune, n)
+	for i := range b {
+		switch {
+		case tag.Edge && g.int63n(8) == 0:
+			b[i] = EdgeRunes[g.int63n(int64(len(EdgeRunes)))]
+		case tag.Marks && i > 0 && !unicode.Is(unicode.Mn, b[i-1]) && g.int63n(4) == 0:
+			b[i] = g.runeFromTable(unicode.Mn)
+		default:
+			b[i] = g.runeFromTable(tag.scripts[g.int63n(int64(len(tag.scripts)))])
+		}
+	}
+	return string(b)
+}
+
+// runeFromTable pick a graphic rune from table
+func (g generator) runeFromTable(table *unicode.RangeTable) rune {
+	var total int64
+	for _, r := range table.R16 {
+		total += int64((r.Hi-r.Lo)/r.Stride) + 1
+	}
+	for _, r := range table.R32 {
+		total += int64((r.Hi-r.Lo)/r.Stride) + 1
+	}
+	for {
+		if r := g.nthRune(table, g.int63n(total)); unicode.IsGraphic(r) {
+			return r
+		}
+	}
+}
+
+func (g generator) nthRune(table *unicode.RangeTable, n int64) rune {
+	for _, r := range table.R16 {
+		size := int64((r.Hi-r.Lo)/r.Stride) + 1
+		if n < size {
+			return rune(r.Lo) + rune(n)*rune(r.Stride)
+		}
+		n -= size
+	}
+	for _, r := range table.R32 {
+		size := int64((r.Hi-r.Lo)/r.Stride) + 1
+		if n < size {
+			return rune(r.Lo) + rune(n)*rune(r.Stride)
+		}
+		n -= size
+	}
+	return 0
+}
+
+// inScripts report whether r is in the scripts of tag, or is a mark or edge rune allowed by tag
+func inScripts(tag Tag, r rune) bool {
+	if tag.Marks && unicode.Is(unicode.Mn, r) {
+		return true
+	}
+	if tag.Edge {
+		for _, e := range EdgeRunes {
+			if e == r {
+				return true
+			}
+		}
+	}
+	for _, table := range tag.scripts {
+		if unicode.Is(table, r) {
+			return true
+		}
+	}
+	return false
+}
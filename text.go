@@ -0,0 +1,243 @@
+package mock
+
+import (
+	"encoding/xml"
+	"html"
+	"io"
+	"strings"
+)
+
+// LoremWords is the word corpus of lorem, paragraph, markdown and html types without locale
+var LoremWords = []string{
+	"lorem", "ipsum", "dolor", "sit", "amet", "consectetur", "adipiscing", "elit", "sed", "do",
+	"eiusmod", "tempor", "incididunt", "ut", "labore", "et", "dolore", "magna", "aliqua", "enim",
+	"ad", "minim", "veniam", "quis", "nostrud", "exercitation", "ullamco", "laboris", "nisi", "aliquip",
+	"ex", "ea", "commodo", "consequat", "duis", "aute", "irure", "in", "reprehenderit", "voluptate",
+	"velit", "esse", "cillum", "fugiat", "nulla", "pariatur", "excepteur", "sint", "occaecat", "cupidatat",
+	"non", "proident", "sunt", "culpa", "qui", "officia", "deserunt", "mollit", "anim", "id",
+	"est", "laborum", "praesent", "vitae", "porta", "mauris", "felis", "integer", "viverra", "nunc",
+}
+
+// textWords return the word corpus, word separator and sentence end of tag
+func textWords(tag Tag) (words []string, sep, end string) {
+	words, sep, end = LoremWords, " ", "."
+	if l := tag.locale; l != nil && len(l.Words) > 0 {
+		words, sep = l.Words, l.WordSeparator
+		if l.SentenceEnd != "" {
+			end = l.SentenceEnd
+		}
+	}
+	return words, sep, end
+}
+
+// lorem return n words starting with "Lorem ipsum", split into sentences of at most 12 words
+func (g generator) lorem(tag Tag, n int64) string {
+	words, sep, end := textWords(tag)
+	var b strings.Builder
+	for i := int64(0); i < n; {
+		size := g.int63n(8) + 4
+		if size > n-i {
+			size = n - i
+		}
+		sentence := make([]string, size)
+		for j := range sentence {
+			switch {
+			case tag.locale == nil && i+int64(j) < 2:
+				sentence[j] = LoremWords[i+int64(j)]
+			default:
+				sentence[j] = g.pick(words)
+			}
+		}
+		if i > 0 {
+			b.WriteString(sep)
+		}
+		b.WriteString(joinSentence(sentence, sep, end))
+		i += size
+	}
+	return b.String()
+}
+
+// textSentence return 4 to 12 random words of the corpus
+func (g generator) textSentence(words []string) []string {
+	sentence := make([]string, g.int63n(9)+4)
+	for i := range sentence {
+		sentence[i] = g.pick(words)
+	}
+	return sentence
+}
+
+// paragraph return n paragraphs of 2 to 5 sentences of the corpus, separated by a blank line
+func (g generator) paragraph(tag Tag, n int64) string {
+	words, sep, end := textWords(tag)
+	paragraphs := make([]string, n)
+	for i := range paragraphs {
+		sentences := make([]string, g.int63n(4)+2)
+		for j := range sentences {
+			sentences[j] = joinSentence(g.textSentence(words), sep, end)
+		}
+		paragraphs[i] = strings.Join(sentences, sep)
+	}
+	return strings.Join(paragraphs, "\n\n")
+}
+
+func joinSentence(words []string, sep, end string) string {
+	if len(words) == 0 {
+		return ""
+	}
+	words[0] = strings.Title(words[0])
+	return strings.Join(words, sep) + end
+}
+
+// document is a random text document rendered by markdown and html types
+type document struct {
+	title    string
+	sections []section
+}
+
+// section is a heading followed by a paragraph and an optional list,
+// the word at link of the first sentence of the paragraph links to url
+type section struct {
+	heading   string
+	sentences [][]string
+	link      int
+	url       string
+	list      []string
+}
+
+// document return a document with n sections, each section has one paragraph,
+// the first section always has a list
+func (g generator) document(tag Tag, n int64) document {
+	words, sep, _ := textWords(tag)
+	title := func() string {
+		w := make([]string, g.int63n(3)+2)
+		for i := range w {
+			w[i] = g.pick(words)
+		}
+		return strings.Title(strings.Join(w, sep))
+	}
+	doc := document{title: title(), sections: make([]section, n)}
+	for i := range doc.sections {
+		s := section{heading: title(), sentences: make([][]string, g.int63n(4)+2), url: g.url()}
+		for j := range s.sentences {
+			s.sentences[j] = g.textSentence(words)
+		}
+		s.link = int(g.int63n(int64(len(s.sentences[0]))))
+		if i == 0 || g.int63n(2) == 0 {
+			s.list = make([]string, g.int63n(4)+2)
+			for j := range s.list {
+				s.list[j] = title()
+			}
+		}
+		doc.sections[i] = s
+	}
+	return doc
+}
+
+// render return the paragraph of s, the linked word is formatted by link
+func (s section) render(sep, end string, link func(word, url string) string) string {
+	sentences := make([]string, len(s.sentences))
+	for i, words := range s.sentences {
+		words = append([]string(nil), words...)
+		words[0] = strings.Title(words[0])
+		if i == 0 {
+			words[s.link] = link(words[s.link], s.url)
+		}
+		sentences[i] = strings.Join(words, sep) + end
+	}
+	return strings.Join(sentences, sep)
+}
+
+// markdown return a markdown document of n paragraphs, each under a level 2 heading
+func (g generator) markdown(tag Tag, n int64) string {
+	_, sep, end := textWords(tag)
+	doc := g.document(tag, n)
+	var b strings.Builder
+	b.WriteString("# " + doc.title + "\n")
+	for _, s := range doc.sections {
+		b.WriteString("\n## " + s.heading + "\n\n")
+		b.WriteString(s.render(sep, end, func(word, url string) string {
+			return "[" + word + "](" + url + ")"
+		}) + "\n")
+		if len(s.list) > 0 {
+			b.WriteString("\n")
+			for _, item := range s.list {
+				b.WriteString("- " + item + "\n")
+			}
+		}
+	}
+	return b.String()
+}
+
+// html return a html document of n paragraphs, each under a h2 heading
+func (g generator) html(tag Tag, n int64) string {
+	_, sep, end := textWords(tag)
+	doc := g.document(tag, n)
+	title := html.EscapeString(doc.title)
+	var b strings.Builder
+	b.WriteString("<!DOCTYPE html>\n<html>\n<head><title>" + title + "</title></head>\n<body>\n")
+	b.WriteString("<h1>" + title + "</h1>\n")
+	for _, s := range doc.sections {
+		b.WriteString("<h2>" + html.EscapeString(s.heading) + "</h2>\n")
+		s.sentences = escapeSentences(s.sentences)
+		b.WriteString("<p>" + s.render(sep, end, func(word, url string) string {
+			return `<a href="` + html.EscapeString(url) + `">` + word + "</a>"
+		}) + "</p>\n")
+		if len(s.list) > 0 {
+			b.WriteString("<ul>\n")
+			for _, item := range s.list {
+				b.WriteString("<li>" + html.EscapeString(item) + "</li>\n")
+			}
+			b.WriteString("</ul>\n")
+		}
+	}
+	b.WriteString("</body>\n</html>\n")
+	return b.String()
+}
+
+func escapeSentences(sentences [][]string) [][]string {
+	ret := make([][]string, len(sentences))
+	for i, words := range sentences {
+		ret[i] = make([]string, len(words))
+		for j, w := range words {
+			ret[i][j] = html.EscapeString(w)
+		}
+	}
+	return ret
+}
+
+// validHTML report whether s is a well-formed document with a html root element
+func validHTML(s string) bool {
+	d := xml.NewDecoder(strings.NewReader(s))
+	root := ""
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			return root == "html"
+		}
+		if err != nil {
+			return false
+		}
+		if start, ok := tok.(xml.StartElement); ok && root == "" {
+			root = start.Name.Local
+		}
+	}
+}
+
+// validText return why s is not a valid value of the text types, or "" if it is valid
+func validText(t Tag, s string) string {
+	ok := true
+	switch t.Type {
+	case "lorem", "paragraph":
+		ok = strings.TrimSpace(s) != ""
+	case "markdown":
+		ok = strings.HasPrefix(s, "# ")
+	case "html":
+		ok = validHTML(s)
+	default:
+		return ""
+	}
+	if !ok {
+		return "not a valid " + t.Type
+	}
+	return ""
+}
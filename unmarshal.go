@@ -0,0 +1,121 @@
+package mock
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+var (
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	scannerType         = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	valuerType          = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+)
+
+// isUnmarshaler report whether the pointer of typ implements encoding.TextUnmarshaler or sql.Scanner,
+// the net types are excluded
+func isUnmarshaler(typ reflect.Type) bool {
+	if _, ok := netTypes[typ]; ok {
+		return false
+	}
+	p := reflect.PtrTo(typ)
+	return p.Implements(textUnmarshalerType) || p.Implements(scannerType)
+}
+
+// hasText report whether t generates a string by type, pattern, tmpl, value, charset or script
+func hasText(t Tag) bool {
+	return t.Type != "" || t.pattern != nil || t.tmpl != nil || len(t.Values) > 0 || t.Charset != "" || len(t.scripts) > 0
+}
+
+// textTag parse tags as a string field, and report whether the tags generate a string,
+// date without format uses time.RFC3339, the format of time.Time text
+func (m *mocker) textTag(tags string) (Tag, bool) {
+	err := m.err
+	t := m.parseTag("string", tags)
+	failed := m.err != err
+	m.err = err
+	if failed || !hasText(t) {
+		return t, false
+	}
+	if t.Type == "date" && t.Format == "" {
+		t.Format = time.RFC3339
+	}
+	return t, true
+}
+
+// mockUnmarshaler mock v by UnmarshalText or Scan with the string generated by tags,
+// and report whether v is mocked, Scan of type(date) receives a time.Time
+func (m *mocker) mockUnmarshaler(tags string, v reflect.Value) bool {
+	t, ok := m.textTag(tags)
+	if !ok {
+		return false
+	}
+	var s string
+	m.mockString(t, reflect.ValueOf(&s).Elem())
+	var err error
+	switch p := v.Addr().Interface().(type) {
+	case encoding.TextUnmarshaler:
+		err = p.UnmarshalText([]byte(s))
+	case sql.Scanner:
+		var src interface{} = s
+		if t.Type == "date" {
+			src, err = time.Parse(t.Format, s)
+		}
+		if err == nil {
+			err = p.Scan(src)
+		}
+	}
+	if err != nil {
+		m.err = NewConflictError("fieldType", v.Type().String(), "tags", tags, fmt.Sprintf("can not unmarshal %q: %v", s, err))
+	}
+	return true
+}
+
+// marshalText return the text of v by MarshalText, Value, String or the string kind
+func marshalText(v reflect.Value) (string, bool) {
+	if v.Type().Implements(textMarshalerType) {
+		b, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+		return string(b), err == nil
+	}
+	if v.Type().Implements(valuerType) {
+		val, err := v.Interface().(driver.Valuer).Value()
+		switch val := val.(type) {
+		case string:
+			return val, err == nil
+		case []byte:
+			return string(val), err == nil
+		case time.Time:
+			return val.Format(time.RFC3339), err == nil
+		case nil:
+			return "", false
+		}
+	}
+	if s, ok := v.Interface().(fmt.Stringer); ok {
+		return s.String(), true
+	}
+	if v.Kind() == reflect.String {
+		return v.String(), true
+	}
+	return "", false
+}
+
+// validUnmarshaler valid the text of v with tags, and report whether v is validated,
+// null values of sql.Scanner are not validated
+func (m *mocker) validUnmarshaler(path, tags string, v reflect.Value) bool {
+	t, ok := m.textTag(tags)
+	if !ok {
+		return false
+	}
+	s, ok := marshalText(v)
+	if !ok {
+		return true
+	}
+	if m.validFunc(path, t, v) {
+		m.validField(path, t, reflect.ValueOf(s))
+	}
+	return true
+}
@@ -0,0 +1,416 @@
+package mock
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// InvalidError descripe the invalid value found by Valid
+type InvalidError struct {
+	Path   string      // field path, e.g. Users[0].Name
+	Value  interface{} // actual value
+	Reason string      // why invalid
+}
+
+func (e InvalidError) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("%v is invalid, %s", e.Value, e.Reason)
+	}
+	return fmt.Sprintf("%s: %v is invalid, %s", e.Path, e.Value, e.Reason)
+}
+
+// NewInvalidError construct a InvalidError
+func NewInvalidError(path string, value interface{}, reason string) error {
+	return InvalidError{
+		Path:   path,
+		Value:  value,
+		Reason: reason,
+	}
+}
+
+func (m *mocker) Valid(tags string, data interface{}) (bool, error) {
+	m.current = data
+	m.err = nil
+	m.valid("", tags, reflect.ValueOf(data))
+	return m.err == nil, m.err
+}
+
+func (m *mocker) valid(path, tags string, v reflect.Value) {
+	if m.err != nil || !v.IsValid() {
+		return
+	}
+	if v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if !v.IsNil() {
+			m.valid(path, tags, v.Elem())
+		}
+		return
+	}
+
+	if m.validCustom(path, tags, v) {
+		return
+	}
+	if m.validEnum(path, v) {
+		return
+	}
+	if isUnmarshaler(v.Type()) && m.validUnmarshaler(path, tags, v) {
+		return
+	}
+	typ, isNet := netTypes[v.Type()]
+	if !isNet {
+		typ = v.Kind().String()
+	}
+	t := m.parseTag(typ, tags)
+	if m.err != nil || !m.validFunc(path, t, v) {
+		return
+	}
+
+	if p, ok := m.types[t.Type]; ok {
+		if reason := p.Valid(t, v.Interface()); reason != "" {
+			m.err = NewInvalidError(path, v.Interface(), reason)
+		}
+		return
+	}
+
+	if s, ok := netString(v); ok {
+		if t.Type == "" {
+			t.Type = defaultNetTypes[typ]
+		}
+		if reason := validNet(t, s); reason != "" {
+			m.err = NewInvalidError(path, s, reason)
+		}
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		m.validStruct(path, v)
+	case reflect.Slice, reflect.Array:
+		if size, ok := idSizes[t.Type]; ok && v.Len() != size {
+			m.err = NewInvalidError(path, v.Interface(), fmt.Sprintf("%s need %d bytes", t.Type, size))
+			return
+		}
+		if blobTypes[t.Type] {
+			if reason := validBlob(t, blobBytes(v)); reason != "" {
+				m.err = NewInvalidError(path, v.Interface(), reason)
+			}
+			return
+		}
+		if reason := validRange(t, v); reason != "" {
+			m.err = NewInvalidError(path, v.Interface(), reason)
+			return
+		}
+		for i := 0; i < v.Len(); i++ {
+			m.valid(fmt.Sprintf("%s[%d]", path, i), t.Elem, v.Index(i))
+		}
+	case reflect.Map:
+		m.validMap(path, t, v)
+	default:
+		m.validField(path, t, v)
+	}
+}
+
+// validFunc valid v by the ValidFunc of valid tag func, and report whether v is valid
+func (m *mocker) validFunc(path string, t Tag, v reflect.Value) bool {
+	if t.ValidFunc == "" {
+		return true
+	}
+	fn, ok := m.validFuncs[t.ValidFunc]
+	if !ok {
+		m.err = NewParamError("valid", "registered ValidFunc", t.ValidFunc)
+		return false
+	}
+	if !fn(v.Interface()) {
+		m.err = NewInvalidError(path, v.Interface(), fmt.Sprintf("valid(%s) failed", t.ValidFunc))
+		return false
+	}
+	return true
+}
+
+func (m *mocker) validStruct(path string, v reflect.Value) {
+	t := v.Type()
+	s := m.structConfig(t)
+	for i := 0; i < v.NumField(); i++ {
+		tf := t.Field(i)
+		name, omitempty, skip := m.fieldName(tf)
+		name = joinPath(path, name)
+		tags := m.fieldTags(name, s, tf)
+		if tf.PkgPath != "" || skip || tags == "-" || omitempty && v.Field(i).IsZero() {
+			continue
+		}
+		m.valid(name, tags, v.Field(i))
+	}
+}
+
+func (m *mocker) validMap(path string, t Tag, v reflect.Value) {
+	if reason := validRange(t, v); reason != "" {
+		m.err = NewInvalidError(path, v.Interface(), reason)
+		return
+	}
+	keys := v.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+	})
+	for _, key := range keys {
+		name := fmt.Sprintf("%s[%v]", path, key)
+		if t.Key != "" {
+			m.valid(name, t.Key, key)
+		}
+		m.valid(name, t.Elem, v.MapIndex(key))
+	}
+}
+
+func (m *mocker) validField(path string, t Tag, v reflect.Value) {
+	val := fieldValue(v)
+	if len(t.Values) > 0 && !inValues(t.Values, val) {
+		m.err = NewInvalidError(path, val, fmt.Sprintf("not in value%v", t.Values))
+		return
+	}
+	if reason := validType(t, v); reason != "" {
+		m.err = NewInvalidError(path, val, reason)
+		return
+	}
+	if t.Charset != "" && v.Kind() == reflect.String {
+		for _, r := range v.String() {
+			if !strings.ContainsRune(t.Charset, r) {
+				m.err = NewInvalidError(path, val, fmt.Sprintf("%q not in charset", r))
+				return
+			}
+		}
+	}
+	if len(t.scripts) > 0 && v.Kind() == reflect.String && t.Type != "sentence" {
+		for _, r := range v.String() {
+			if !inScripts(t, r) {
+				m.err = NewInvalidError(path, val, fmt.Sprintf("%q not in script(%s)", r, strings.Join(t.Scripts, "|")))
+				return
+			}
+		}
+	}
+	if t.pattern != nil && v.Kind() == reflect.String {
+		if !m.compilePattern(t.Pattern).MatchString(v.String()) {
+			m.err = NewInvalidError(path, val, fmt.Sprintf("not match pattern(%s)", t.Pattern))
+			return
+		}
+	}
+	if reason := validRange(t, v); reason != "" {
+		m.err = NewInvalidError(path, val, reason)
+	}
+}
+
+// validRange valid the numbers, and the lengths of strings, slices and maps by the range tag func,
+// the ranges of values, patterns, templates and the types other than strings are not checked,
+// lengths of strings count runes
+func validRange(t Tag, v reflect.Value) string {
+	if t.Type != "" && (v.Kind() != reflect.String || !t.typeLength()) {
+		return ""
+	}
+	if !t.ranged || len(t.Values) > 0 || t.pattern != nil || t.tmpl != nil || t.Marks {
+		return ""
+	}
+	switch v.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map:
+		n := int64(v.Len())
+		if v.Kind() == reflect.String {
+			n = int64(utf8.RuneCountInString(v.String()))
+		}
+		if lo, hi := t.intBounds(); n < lo || n > hi {
+			return fmt.Sprintf("length %d not in range[%d, %d]", n, lo, hi)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if lo, hi := t.intBounds(); v.Int() < lo || v.Int() > hi {
+			return fmt.Sprintf("not in range[%d, %d]", lo, hi)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if lo, hi := t.uintBounds(); v.Uint() < lo || v.Uint() > hi {
+			return fmt.Sprintf("not in range[%d, %d]", lo, hi)
+		}
+	case reflect.Float32, reflect.Float64:
+		min, max := t.floatBounds()
+		n := v.Float()
+		minExclusive, maxInclusive := t.MinExclusive, t.MaxInclusive
+		if v.Kind() == reflect.Float32 {
+			// the generated float64 may be rounded to the bounds
+			min, max = float64(float32(min)), float64(float32(max))
+			minExclusive, maxInclusive = false, true
+		}
+		if n < min || n == min && minExclusive || n > max || n == max && !maxInclusive && min != max {
+			lb, rb := "[", ")"
+			if minExclusive {
+				lb = "("
+			}
+			if maxInclusive {
+				rb = "]"
+			}
+			return fmt.Sprintf("not in range%s%v, %v%s", lb, min, max, rb)
+		}
+	}
+	return ""
+}
+
+// compilePattern compile the pattern to match whole string, the result is cached
+func (m *mocker) compilePattern(pattern string) *regexp.Regexp {
+	if re, ok := m.patterns[pattern]; ok {
+		return re
+	}
+	if m.patterns == nil {
+		m.patterns = make(map[string]*regexp.Regexp)
+	}
+	re := regexp.MustCompile(`^(?:` + pattern + `)$`)
+	m.patterns[pattern] = re
+	return re
+}
+
+// fieldValue return the value of v in the types used by Tag.Values
+func fieldValue(v reflect.Value) interface{} {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint()
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return v.Bool()
+	}
+	return v.Interface()
+}
+
+func inValues(vals []interface{}, val interface{}) bool {
+	for _, v := range vals {
+		if v == val {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	emailRe    = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+	phoneRe    = regexp.MustCompile(`^\+?[0-9][0-9 ()-]{5,}[0-9]$`)
+	domainRe   = regexp.MustCompile(`^(?i)([a-z0-9]([a-z0-9-]*[a-z0-9])?\.)+[a-z]{2,}$`)
+	nameRe     = regexp.MustCompile(`^[\pL\pM][\pL\pM .'&-]*$`)
+	usernameRe = regexp.MustCompile(`^[a-z0-9][a-z0-9._]*$`)
+	streetRe   = regexp.MustCompile(`\pL.*\d|\d.*\pL`)
+)
+
+// validType return why v is not a valid value of t.Type, or "" if it is valid
+func validType(t Tag, v reflect.Value) string {
+	if t.Type == "" {
+		return ""
+	}
+	if t.Type == "port" && v.Kind() != reflect.String {
+		return validNet(t, fmt.Sprint(fieldValue(v)))
+	}
+	if t.Type == "snowflake" && v.Kind() != reflect.String {
+		return validID(t, fmt.Sprint(fieldValue(v)))
+	}
+	if _, ok := idSizes[t.Type]; ok && v.Kind() != reflect.String {
+		return ""
+	}
+	if t.Type == "amount" && (v.Kind() == reflect.Float32 || v.Kind() == reflect.Float64) && !validAmount(t, v.Float(), v.Type().Bits()) {
+		return "not a valid amount"
+	}
+	if t.Type == "latitude" || t.Type == "longitude" {
+		var n float64
+		switch v.Kind() {
+		case reflect.Float32, reflect.Float64:
+			n = v.Float()
+		case reflect.String:
+			var err error
+			if n, err = strconv.ParseFloat(v.String(), 64); err != nil {
+				return t.Type + " need a number"
+			}
+		}
+		limit := 90.0
+		if t.Type == "longitude" {
+			limit = 180
+		}
+		if n < -limit || n > limit {
+			return t.Type + " out of range"
+		}
+		return ""
+	}
+	if v.Kind() != reflect.String {
+		return ""
+	}
+
+	s := v.String()
+	ok := true
+	switch t.Type {
+	case "email", "eamil":
+		ok = emailRe.MatchString(s)
+	case "date":
+		format := TimeFormat
+		if t.Format != "" {
+			format = t.Format
+		}
+		_, err := time.Parse(format, s)
+		ok = err == nil
+	case "phone":
+		ok = phoneRe.MatchString(s)
+	case "domain":
+		ok = domainRe.MatchString(s)
+	case "firstname", "lastname", "fullname", "city", "state", "country", "jobtitle":
+		ok = nameRe.MatchString(s)
+	case "username":
+		ok = usernameRe.MatchString(s)
+	case "street":
+		ok = streetRe.MatchString(s)
+	case "zip":
+		ok = false
+		for _, f := range tagLocale(t).PostalFormats {
+			if formatRegexp(f).MatchString(s) {
+				ok = true
+			}
+		}
+	case "company":
+		ok = strings.TrimSpace(s) != ""
+	}
+	if !ok {
+		return "not a valid " + t.Type
+	}
+	if reason := validNet(t, s); reason != "" {
+		return reason
+	}
+	if reason := validID(t, s); reason != "" {
+		return reason
+	}
+	if reason := validFinance(t, s); reason != "" {
+		return reason
+	}
+	if reason := validText(t, s); reason != "" {
+		return reason
+	}
+	if reason := validBlob(t, []byte(s)); reason != "" {
+		return reason
+	}
+	return validDev(t, s)
+}
+
+// formatRegexp return the regular expression matching the locale format f
+func formatRegexp(f string) *regexp.Regexp {
+	f = regexp.QuoteMeta(f)
+	f = strings.Replace(f, "#", `\d`, -1)
+	f = strings.Replace(f, "%s", `.+`, -1)
+	return regexp.MustCompile("^" + f + "$")
+}
+
+// typeLength report whether the range of t bounds the length of the string of the built-in type,
+// the range of net types is the subnet
+func (t Tag) typeLength() bool {
+	_, ok := stringTypes[t.Type]
+	return ok && t.ranged && t.Subnet == nil && !rangeTypes[t.Type] && len(t.Values) == 0 && t.pattern == nil && t.tmpl == nil
+}
+
+// inLength report whether the rune count of s is in [lo, hi]
+func inLength(s string, lo, hi int64) bool {
+	n := int64(utf8.RuneCountInString(s))
+	return n >= lo && n <= hi
+}
@@ -0,0 +1,42 @@
+package mock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValid(t *testing.T) {
+	m := New(time.Now().UnixNano(), nil)
+	m.SetValidFuncs(ValidFuncs{
+		"even": func(data interface{}) bool { return data.(int)%2 == 0 },
+	})
+
+	type N struct {
+		ID    string   `mock:"pattern([A-Z]{3}-\\d{4})"`
+		Level int      `mock:"valid(even)"`
+		Tags  []string `mock:"elem(value(a, b))"`
+		Skip  string   `mock:"-"`
+	}
+
+	ok, err := m.Valid("", &N{ID: "ABC-1234", Level: 2, Tags: []string{"a", "b"}})
+	assert.True(t, ok)
+	assert.Nil(t, err)
+
+	ok, err = m.Valid("", N{ID: "AB-1234", Level: 2})
+	assert.False(t, ok)
+	assert.Equal(t, "ID", err.(InvalidError).Path)
+
+	ok, err = m.Valid("", N{ID: "ABC-1234", Level: 3})
+	assert.False(t, ok)
+	assert.Equal(t, "Level", err.(InvalidError).Path)
+
+	ok, err = m.Valid("", N{ID: "ABC-1234", Tags: []string{"a", "c"}})
+	assert.False(t, ok)
+	assert.Equal(t, "Tags[1]", err.(InvalidError).Path)
+
+	ok, err = m.Valid("valid(unknown)", 1)
+	assert.False(t, ok)
+	assert.IsType(t, ParamError{}, err)
+}
@@ -0,0 +1,164 @@
+package mock
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// validatorTypes map the go-playground/validator rules to the types and formats
+var validatorTypes = map[string][2]string{
+	"email": {"email", ""},
+	"url":   {"url", ""},
+	"uri":   {"uri", ""},
+	"uuid":  {"uuid", ""},
+	"uuid4": {"uuid", "v4"},
+	"uuid7": {"uuid", "v7"},
+	"ipv4":  {"ipv4", ""},
+	"ipv6":  {"ipv6", ""},
+	"cidr":  {"cidr", ""},
+	"mac":   {"mac", ""},
+}
+
+func (m *mocker) SetValidateTag(key string) {
+	m.validateTag = key
+}
+
+// validatorBounds is the bounds of min, max, len, gt, gte, lt and lte
+type validatorBounds struct {
+	lo, hi       string
+	minExclusive bool
+	maxInclusive bool
+}
+
+// ValidatorTags translate the rules of a go-playground/validator tag to the tags of a field of typ,
+// e.g. "required,min=3,max=20" of a string to "range([3, 20])". min, max, len, gt, gte, lt and lte
+// bound the length of strings, slices and maps and the value of numbers, oneof is translated to value,
+// email, url, uuid, ipv4, ipv6, cidr and mac to type, the rules after dive apply to the elements, and the rules between
+// keys and endkeys apply to the map keys, the other rules are ignored
+func ValidatorTags(rules string, typ reflect.Type) (string, error) {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	length := hasLength(typ.Kind())
+	var tags []string
+	var b validatorBounds
+	list := strings.Split(rules, ",")
+	for i := 0; i < len(list); i++ {
+		rule := strings.TrimSpace(list[i])
+		name, param := rule, ""
+		if j := strings.IndexByte(rule, '='); j >= 0 {
+			name, param = rule[:j], rule[j+1:]
+		}
+		switch name {
+		case "min", "gte":
+			b.lo, b.minExclusive = param, false
+		case "gt":
+			b.lo, b.minExclusive = param, true
+		case "max", "lte":
+			b.hi, b.maxInclusive = param, true
+		case "lt":
+			b.hi, b.maxInclusive = param, false
+		case "len":
+			b.lo, b.hi, b.minExclusive, b.maxInclusive = param, param, false, true
+		case "oneof":
+			tags = append(tags, "value("+strings.Join(strings.Fields(param), ", ")+")")
+		case "dive":
+			k := typ.Kind()
+			if k != reflect.Slice && k != reflect.Array && k != reflect.Map {
+				return "", NewConflictError("fieldType", typ.String(), "validate", rule, "dive need a slice, array or map field")
+			}
+			rest := list[i+1:]
+			if k == reflect.Map && len(rest) > 0 && strings.TrimSpace(rest[0]) == "keys" {
+				end := indexOf(rest, "endkeys")
+				if end < 0 {
+					return "", NewParamError("validate", "keys with endkeys", rules)
+				}
+				key, err := ValidatorTags(strings.Join(rest[1:end], ","), typ.Key())
+				if err != nil {
+					return "", err
+				}
+				if key != "" {
+					tags = append(tags, "key("+key+")")
+				}
+				rest = rest[end+1:]
+			}
+			elem, err := ValidatorTags(strings.Join(rest, ","), typ.Elem())
+			if err != nil {
+				return "", err
+			}
+			if elem != "" {
+				tags = append(tags, "elem("+elem+")")
+			}
+			i = len(list)
+		default:
+			if t, ok := validatorTypes[name]; ok {
+				tags = append(tags, "type("+t[0]+")")
+				if t[1] != "" {
+					tags = append(tags, "format("+t[1]+")")
+				}
+			}
+		}
+	}
+	r, err := b.tag(typ, length)
+	if err != nil {
+		return "", err
+	}
+	if r != "" {
+		tags = append([]string{r}, tags...)
+	}
+	return strings.Join(tags, " "), nil
+}
+
+// tag return the range tag func of the bounds, the lengths without min are at least 1 and
+// the lengths without max are at most min+9
+func (b validatorBounds) tag(typ reflect.Type, length bool) (string, error) {
+	if b.lo == "" && b.hi == "" || !length && !isNumber(typ.Kind().String()) {
+		return "", nil
+	}
+	for _, n := range []string{b.lo, b.hi} {
+		if n == "" {
+			continue
+		}
+		var err error
+		if length {
+			_, err = strconv.ParseUint(n, 10, 63)
+		} else {
+			_, err = strconv.ParseFloat(n, 64)
+		}
+		if err != nil {
+			return "", NewParamError("validate", "number", n)
+		}
+	}
+	lo, hi := b.lo, b.hi
+	if length && lo == "" {
+		lo = "1"
+		if hi == "0" || hi == "1" && !b.maxInclusive {
+			lo = "0"
+		}
+	}
+	if length && hi == "" {
+		n, _ := strconv.ParseInt(lo, 10, 64)
+		hi = strconv.FormatInt(n+10, 10)
+	}
+	if b.minExclusive {
+		lo = "]" + lo
+	}
+	if b.maxInclusive || hi == "" {
+		hi += "]"
+	}
+	return "range(" + lo + ", " + hi + ")", nil
+}
+
+func hasLength(k reflect.Kind) bool {
+	return k == reflect.String || k == reflect.Slice || k == reflect.Map
+}
+
+func indexOf(list []string, s string) int {
+	for i, v := range list {
+		if strings.TrimSpace(v) == s {
+			return i
+		}
+	}
+	return -1
+}